@@ -0,0 +1,41 @@
+package bucket
+
+// NewArrayPool creates a new *Pool[[]T] whose slices always have a length
+// (not just a capacity) of exactly size, like a reusable [size]T array.
+//
+// Every element is zeroed when a slice is returned to the pool, so data from
+// one use can never leak into the next. This differs from a typical slice
+// pool, whose reset only truncates with s = s[:0]: that is cheaper, but
+// leaves stale elements in the backing array and hands out empty slices.
+// If the caller reslices or replaces the slice, the reset restores its full
+// length, allocating a new backing array if the capacity has become too small.
+//
+// Example:
+//
+//	var windows = bucket.NewArrayPool[float64](60)
+//
+//	window := windows.Get()
+//	defer windows.Put(window)
+//	(*window)[second] += value
+//
+// Panics if size is less than or equal to zero.
+func NewArrayPool[T any](size int) *Pool[[]T] {
+	if size <= 0 {
+		panic("bucket.NewArrayPool: size must be greater than 0")
+	}
+
+	return New(
+		func() *[]T {
+			s := make([]T, size)
+			return &s
+		},
+		func(s *[]T) {
+			if cap(*s) < size {
+				*s = make([]T, size)
+				return
+			}
+			*s = (*s)[:size]
+			clear(*s)
+		},
+	)
+}
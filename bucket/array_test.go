@@ -0,0 +1,41 @@
+package bucket
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestArrayPool_FixedLengthAndZeroed verifies that slices always have the configured length and no stale data.
+func TestArrayPool_FixedLengthAndZeroed(t *testing.T) {
+	pool := NewArrayPool[float64](4)
+
+	s := pool.Get()
+	assert.Len(t, *s, 4)
+
+	(*s)[0], (*s)[3] = 1.5, 2.5
+	pool.Put(s)
+
+	assert.Len(t, *s, 4)
+	assert.Equal(t, []float64{0, 0, 0, 0}, *s, "Reset should zero every element")
+}
+
+// TestArrayPool_RestoresLength verifies that resliced or replaced slices are restored to full length.
+func TestArrayPool_RestoresLength(t *testing.T) {
+	pool := NewArrayPool[int](3)
+
+	s := pool.Get()
+	(*s)[2] = 7
+	*s = (*s)[:1]
+	pool.Put(s)
+	assert.Equal(t, []int{0, 0, 0}, *s, "Truncated slices should be re-extended and zeroed")
+
+	*s = nil
+	pool.Put(s)
+	assert.Equal(t, []int{0, 0, 0}, *s, "Slices with insufficient capacity should be reallocated")
+}
+
+// TestNewArrayPool_PanicsOnInvalidSize verifies that a non-positive size is rejected.
+func TestNewArrayPool_PanicsOnInvalidSize(t *testing.T) {
+	assert.Panics(t, func() { NewArrayPool[int](0) })
+}
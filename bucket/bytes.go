@@ -0,0 +1,150 @@
+package bucket
+
+import (
+	"slices"
+	"sync/atomic"
+)
+
+// ClassStats is a snapshot of the activity of one BytesPool size class.
+type ClassStats struct {
+	Gets     uint64 // Slices handed out for requests served by this class.
+	Puts     uint64 // Slices returned to this class for reuse.
+	Discards uint64 // Returned slices dropped instead of pooled, see BytesPool.Put.
+}
+
+// BytesPool is a pool of []byte slices grouped into size classes, so that
+// small requests do not pin large buffers and vice versa.
+//
+// Each class keeps slices with a capacity of exactly its size. Use Stats to
+// find out which classes are hot and tune the boundaries to match real
+// request patterns.
+type BytesPool struct {
+	classes []*bytesClass // Sorted by ascending size.
+}
+
+// bytesClass is a single size class of a BytesPool.
+type bytesClass struct {
+	size int
+	pool *Pool[[]byte]
+
+	gets     atomic.Uint64
+	puts     atomic.Uint64
+	discards atomic.Uint64
+}
+
+// NewBytesPool creates a BytesPool with one size class per given size, in
+// bytes. The sizes need not be sorted.
+//
+// Example:
+//
+//	var payloads = bucket.NewBytesPool(1<<10, 16<<10, 256<<10)
+//
+//	buf := payloads.Get(n)
+//	defer payloads.Put(buf)
+//
+// Panics if no sizes are given, or if any size is non-positive or repeated.
+func NewBytesPool(sizes ...int) *BytesPool {
+	if len(sizes) == 0 {
+		panic("bucket.NewBytesPool: at least one size class is required")
+	}
+
+	sorted := slices.Clone(sizes)
+	slices.Sort(sorted)
+
+	p := &BytesPool{classes: make([]*bytesClass, len(sorted))}
+	for i, size := range sorted {
+		if size <= 0 {
+			panic("bucket.NewBytesPool: size classes must be greater than 0")
+		}
+		if i > 0 && size == sorted[i-1] {
+			panic("bucket.NewBytesPool: size classes must be unique")
+		}
+
+		p.classes[i] = &bytesClass{
+			size: size,
+			pool: New(
+				func() *[]byte {
+					b := make([]byte, 0, size)
+					return &b
+				},
+				func(b *[]byte) {
+					*b = (*b)[:0]
+				},
+			),
+		}
+	}
+	return p
+}
+
+// Get returns a slice of length n from the smallest class that can hold it.
+// The contents are not zeroed; overwrite them before reading.
+//
+// If n exceeds the largest class, a new slice is allocated instead. It is
+// counted as a get of the largest class and discarded when returned. A
+// negative n is treated as 0.
+func (p *BytesPool) Get(n int) *[]byte {
+	n = max(n, 0)
+	c := p.classFor(n)
+	c.gets.Add(1)
+
+	if n > c.size {
+		b := make([]byte, n)
+		return &b
+	}
+
+	b := c.pool.Get()
+	*b = (*b)[:n]
+	return b
+}
+
+// Put returns b to the class matching its capacity. Nil slices are ignored.
+//
+// Slices whose capacity does not match a class exactly, such as oversized
+// allocations or slices grown by append, are dropped rather than pooled and
+// counted as discards of the class Get would pick for their capacity.
+func (p *BytesPool) Put(b *[]byte) {
+	if b == nil {
+		return
+	}
+
+	c := p.classFor(cap(*b))
+	if cap(*b) != c.size {
+		c.discards.Add(1)
+		return
+	}
+	c.puts.Add(1)
+	c.pool.Put(b)
+}
+
+// classFor returns the smallest class that can hold n bytes, or the largest
+// class if none can.
+func (p *BytesPool) classFor(n int) *bytesClass {
+	for _, c := range p.classes {
+		if n <= c.size {
+			return c
+		}
+	}
+	return p.classes[len(p.classes)-1]
+}
+
+// Stats returns a snapshot of the counters of every class, keyed by class
+// size. Counters are updated atomically on the hot path, so the snapshot is
+// cheap but not taken at a single instant across classes.
+func (p *BytesPool) Stats() map[int]ClassStats {
+	stats := make(map[int]ClassStats, len(p.classes))
+	for _, c := range p.classes {
+		stats[c.size] = ClassStats{
+			Gets:     c.gets.Load(),
+			Puts:     c.puts.Load(),
+			Discards: c.discards.Load(),
+		}
+	}
+	return stats
+}
+
+// Drain discards the slices retained by every class. Counters are kept.
+func (p *BytesPool) Drain() {
+	for _, c := range p.classes {
+		c.pool.Drain()
+	}
+}
@@ -0,0 +1,80 @@
+package bucket
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestBytesPool_GetPicksSmallestClass verifies that slices come from the smallest class that fits.
+func TestBytesPool_GetPicksSmallestClass(t *testing.T) {
+	pool := NewBytesPool(4096, 64, 1024)
+
+	b := pool.Get(100)
+	assert.Len(t, *b, 100)
+	assert.Equal(t, 1024, cap(*b))
+	pool.Put(b)
+
+	b = pool.Get(64)
+	assert.Equal(t, 64, cap(*b), "Requests equal to a class size should use that class")
+	pool.Put(b)
+}
+
+// TestBytesPool_GetNegative verifies that a negative length is treated as 0.
+func TestBytesPool_GetNegative(t *testing.T) {
+	pool := NewBytesPool(64, 1024)
+
+	b := pool.Get(-1)
+	assert.Len(t, *b, 0)
+	assert.Equal(t, 64, cap(*b), "Negative requests should use the smallest class")
+	pool.Put(b)
+}
+
+// TestBytesPool_Stats verifies that gets, puts, and discards are counted per class.
+func TestBytesPool_Stats(t *testing.T) {
+	pool := NewBytesPool(64, 1024)
+
+	for i := 0; i < 3; i++ {
+		pool.Put(pool.Get(10))
+	}
+	pool.Put(pool.Get(500))
+
+	oversized := pool.Get(5000)
+	assert.Len(t, *oversized, 5000)
+	pool.Put(oversized)
+
+	grown := pool.Get(10)
+	*grown = append(*grown, make([]byte, 100)...)
+	pool.Put(grown)
+
+	stats := pool.Stats()
+	assert.Equal(t, ClassStats{Gets: 4, Puts: 3}, stats[64])
+	assert.Equal(t, ClassStats{Gets: 2, Puts: 1, Discards: 2}, stats[1024], "Oversized and grown slices should be discarded by the class matching their capacity")
+}
+
+// TestBytesPool_Concurrent verifies that the counters stay exact under concurrent use.
+func TestBytesPool_Concurrent(t *testing.T) {
+	pool := NewBytesPool(128)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 1000; j++ {
+				pool.Put(pool.Get(j % 128))
+			}
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, ClassStats{Gets: 8000, Puts: 8000}, pool.Stats()[128])
+}
+
+// TestNewBytesPool_PanicsOnInvalidSizes verifies that missing, non-positive, and duplicate sizes are rejected.
+func TestNewBytesPool_PanicsOnInvalidSizes(t *testing.T) {
+	assert.Panics(t, func() { NewBytesPool() })
+	assert.Panics(t, func() { NewBytesPool(0, 64) })
+	assert.Panics(t, func() { NewBytesPool(64, 64) })
+}
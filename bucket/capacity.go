@@ -0,0 +1,46 @@
+package bucket
+
+import "sync/atomic"
+
+// currentCapacity holds the capacity set by SetDefaultCapacity; zero means DefaultCapacity.
+var currentCapacity atomic.Int64
+
+// defaultCapacity returns the current default capacity for pooled buffers.
+func defaultCapacity() int {
+	if n := currentCapacity.Load(); n > 0 {
+		return int(n)
+	}
+	return DefaultCapacity
+}
+
+// SetDefaultCapacity changes the initial capacity of buffers created by the
+// global pools (ByteBucket and StringBuilderBucket) and by
+// constructors called with a non-positive capacity. A value less than or
+// equal to zero restores DefaultCapacity.
+//
+// Buffers already in the global pools keep their size until they are
+// discarded; call ResetGlobalPools afterwards to drop them right away. Both
+// functions are safe to call concurrently with pool use, but are intended to
+// be called once at startup, before heavy use:
+//
+//	func init() {
+//		bucket.SetDefaultCapacity(64 * 1024)
+//		bucket.ResetGlobalPools()
+//	}
+func SetDefaultCapacity(n int) {
+	if n <= 0 {
+		n = 0
+	}
+	currentCapacity.Store(int64(n))
+}
+
+// ResetGlobalPools discards every object retained by the global buffer pools
+// (ByteBucket and StringBuilderBucket), so that subsequent Gets construct
+// fresh objects at the current default capacity.
+//
+// Objects checked out at the time of the call are unaffected and may still be
+// returned with Put. Pools created with explicit capacities are not affected.
+func ResetGlobalPools() {
+	ByteBucket.Drain()
+	StringBuilderBucket.Drain()
+}
@@ -0,0 +1,32 @@
+package bucket
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestSetDefaultCapacity verifies that the global pools pick up a new default capacity after a reset.
+func TestSetDefaultCapacity(t *testing.T) {
+	t.Cleanup(func() {
+		SetDefaultCapacity(0)
+		ResetGlobalPools()
+	})
+
+	SetDefaultCapacity(64 * 1024)
+	ResetGlobalPools()
+
+	buf := ByteBucket.Get()
+	assert.Equal(t, 64*1024, buf.Cap())
+	ByteBucket.Put(buf)
+
+	sb := StringBuilderBucket.Get()
+	assert.GreaterOrEqual(t, sb.Cap(), 64*1024)
+	StringBuilderBucket.Put(sb)
+
+	assert.Equal(t, 64*1024, NewBytePool(0).Get().Cap(), "Constructors should use the new default")
+	assert.Equal(t, 128, NewBytePool(128).Get().Cap(), "Explicit capacities are unaffected")
+
+	SetDefaultCapacity(-1)
+	assert.Equal(t, DefaultCapacity, defaultCapacity(), "Non-positive values restore the default")
+}
@@ -1,13 +1,51 @@
 package bucket
 
-import "sync"
+import (
+	"sync"
+	"sync/atomic"
+)
 
 // Pool is a type-safe wrapper around sync.Pool.
 // It ensures objects are properly reset before being reused,
 // preventing state leakage and reducing garbage collector pressure.
 type Pool[T any] struct {
-	pool  *sync.Pool
-	reset func(*T) // Reset function called before returning an object to the pool.
+	pool    atomic.Pointer[sync.Pool] // Swapped out by Drain to release retained objects.
+	newFunc func() *T
+	reset   func(*T) // Reset function called before returning an object to the pool.
+
+	onGet func(*T) // Optional instrumentation hook, see WithOnGet.
+	onPut func(*T) // Optional instrumentation hook, see WithOnPut.
+
+	onResetPanic func(recovered any) // Optional, see WithOnResetPanic.
+
+	idle *idleStore[T] // Replaces pool, which is then left nil, when WithIdleTTL is set.
+
+	stats atomic.Pointer[poolCounters] // Nil unless enabled, see WithStats.
+}
+
+// poolCounters holds the counters reported by Pool.Stats.
+type poolCounters struct {
+	gets     atomic.Uint64
+	misses   atomic.Uint64
+	puts     atomic.Uint64
+	discards atomic.Uint64
+}
+
+// PoolStats is a snapshot of the activity of a Pool.
+type PoolStats struct {
+	Gets     uint64 // Objects handed out by Get.
+	Misses   uint64 // Gets that had to construct a new object.
+	Puts     uint64 // Objects returned for reuse.
+	Discards uint64 // Returned objects dropped because their reset panicked.
+}
+
+// HitRate returns the fraction of Gets served by a reused object, or 0 if
+// there have been no Gets.
+func (s PoolStats) HitRate() float64 {
+	if s.Gets == 0 || s.Misses >= s.Gets {
+		return 0
+	}
+	return float64(s.Gets-s.Misses) / float64(s.Gets)
 }
 
 // New creates a new type-safe Pool for the given type T.
@@ -16,19 +54,75 @@ type Pool[T any] struct {
 // The resetFunc parameter is required and is automatically called on every
 // object before it is put back into the pool.
 //
+// Optional behavior, such as instrumentation hooks, can be enabled by passing
+// Option values.
+//
 // Panics if resetFunc is nil.
-func New[T any](newFunc func() *T, resetFunc func(*T)) *Pool[T] {
+func New[T any](newFunc func() *T, resetFunc func(*T), opts ...Option[T]) *Pool[T] {
 	if resetFunc == nil {
 		panic("bucket.New: resetFunc must not be nil")
 	}
 
-	return &Pool[T]{
-		pool: &sync.Pool{
-			New: func() any {
-				return newFunc()
-			},
+	p := &Pool[T]{
+		newFunc: newFunc,
+		reset:   resetFunc,
+	}
+	for _, opt := range opts {
+		opt(p)
+	}
+	if p.idle == nil {
+		p.pool.Store(p.newSyncPool())
+	}
+	return p
+}
+
+// newSyncPool creates an empty sync.Pool backed by the pool's constructor.
+func (p *Pool[T]) newSyncPool() *sync.Pool {
+	return &sync.Pool{
+		New: func() any {
+			if c := p.stats.Load(); c != nil {
+				c.misses.Add(1)
+			}
+			return p.newFunc()
 		},
-		reset: resetFunc,
+	}
+}
+
+// Drain discards every object currently retained by the pool, making its
+// memory reclaimable by the next garbage collection.
+//
+// sync.Pool already sheds its contents over GC cycles; Drain releases them
+// proactively, e.g. in response to memory pressure. Objects checked out at
+// the time of the call are unaffected and may still be returned with Put.
+func (p *Pool[T]) Drain() {
+	if p.idle != nil {
+		p.idle.drain()
+		return
+	}
+	p.pool.Store(p.newSyncPool())
+}
+
+// EnableStats starts counting the pool's activity, like WithStats, for pools
+// that are already constructed, such as ByteBucket. Counts start at zero; it
+// does nothing if counting is already enabled. It is safe to call
+// concurrently with Get and Put.
+func (p *Pool[T]) EnableStats() {
+	p.stats.CompareAndSwap(nil, new(poolCounters))
+}
+
+// Stats returns a snapshot of the pool's counters, or zero stats if counting
+// is not enabled (see WithStats). The counters are read individually, so a
+// snapshot taken under load may be slightly inconsistent.
+func (p *Pool[T]) Stats() PoolStats {
+	c := p.stats.Load()
+	if c == nil {
+		return PoolStats{}
+	}
+	return PoolStats{
+		Gets:     c.gets.Load(),
+		Misses:   c.misses.Load(),
+		Puts:     c.puts.Load(),
+		Discards: c.discards.Load(),
 	}
 }
 
@@ -38,17 +132,83 @@ func New[T any](newFunc func() *T, resetFunc func(*T)) *Pool[T] {
 // The caller is responsible for returning it to the pool via Put().
 // Typically used with `defer p.Put(obj)` for safety.
 func (p *Pool[T]) Get() *T {
-	return p.pool.Get().(*T)
+	var obj *T
+	if p.idle != nil {
+		var ok bool
+		if obj, ok = p.idle.get(); !ok {
+			if c := p.stats.Load(); c != nil {
+				c.misses.Add(1)
+			}
+			obj = p.newFunc()
+		}
+	} else {
+		obj = p.pool.Load().Get().(*T)
+	}
+	if c := p.stats.Load(); c != nil {
+		c.gets.Add(1)
+	}
+	if p.onGet != nil {
+		p.onGet(obj)
+	}
+	return obj
 }
 
 // Put returns the given object to the pool after calling its reset function.
 // Nil objects are ignored.
+//
+// If the reset function panics, the panic is recovered and the object is
+// dropped instead of being pooled, since its state is unknown. The panic
+// value is reported to the WithOnResetPanic hook, if any.
 func (p *Pool[T]) Put(obj *T) {
 	if obj == nil {
 		return
 	}
+	if p.onPut != nil {
+		p.onPut(obj)
+	}
+	c := p.stats.Load()
+	if !p.tryReset(obj) {
+		if c != nil {
+			c.discards.Add(1)
+		}
+		return
+	}
+	if c != nil {
+		c.puts.Add(1)
+	}
+	if p.idle != nil {
+		p.idle.put(obj)
+		return
+	}
+	p.pool.Load().Put(obj)
+}
+
+// tryReset resets obj and reports whether the reset function completed
+// without panicking.
+func (p *Pool[T]) tryReset(obj *T) (ok bool) {
+	defer func() {
+		if r := recover(); r != nil {
+			ok = false
+			if p.onResetPanic != nil {
+				p.onResetPanic(r)
+			}
+		}
+	}()
+
 	p.reset(obj)
-	p.pool.Put(obj)
+	return true
+}
+
+// Acquire is an alias for Get, for code that treats pooled objects as
+// borrowed resource handles. Pair every Acquire with a Release.
+func (p *Pool[T]) Acquire() *T {
+	return p.Get()
+}
+
+// Release is an alias for Put: it resets obj and returns it to the pool.
+// The caller must not use obj after releasing it.
+func (p *Pool[T]) Release(obj *T) {
+	p.Put(obj)
 }
 
 // --- Pattern 2: Automatic Callback (Safe) ---
@@ -108,6 +108,22 @@ func BenchmarkJSONEncoding_CassieWith(b *testing.B) {
 	})
 }
 
+// BenchmarkJSONEncoding_CassieEncoder benchmarks pooled buffers combined with a pooled, rebindable encoder.
+func BenchmarkJSONEncoding_CassieEncoder(b *testing.B) {
+	benchmarkWithLatency(b, func() error {
+		return WithByteBufferErr(func(buf *bytes.Buffer) error {
+			err := WithJSONEncoder(buf, func(enc *JSONEncoder) error {
+				return enc.Encode(largeProductList)
+			})
+			if err != nil {
+				return err
+			}
+			_, err = io.Discard.Write(buf.Bytes())
+			return err
+		})
+	})
+}
+
 // BenchmarkJSONEncoding_Standard_Parallel benchmarks JSON encoding (standard lib) under parallel load.
 func BenchmarkJSONEncoding_Standard_Parallel(b *testing.B) {
 	b.ReportAllocs()
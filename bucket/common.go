@@ -12,13 +12,22 @@ const DefaultCapacity = 4096
 
 // NewBytePool creates a new *Pool[bytes.Buffer] with the given initial capacity.
 // The buffer will be automatically reset when returned to the pool.
+//
+// A capacity less than or equal to zero selects the current default capacity
+// (see SetDefaultCapacity).
 func NewBytePool(initialCapacity int) *Pool[bytes.Buffer] {
 	if initialCapacity <= 0 {
-		initialCapacity = DefaultCapacity
+		initialCapacity = defaultCapacity()
 	}
+	return newBytePool(func() int { return initialCapacity })
+}
+
+// newBytePool creates a byte buffer pool whose new buffers have the capacity
+// reported by capacity at the time they are constructed.
+func newBytePool(capacity func() int) *Pool[bytes.Buffer] {
 	return New(
 		func() *bytes.Buffer {
-			return bytes.NewBuffer(make([]byte, 0, initialCapacity))
+			return bytes.NewBuffer(make([]byte, 0, capacity()))
 		},
 		func(b *bytes.Buffer) {
 			b.Reset()
@@ -28,14 +37,23 @@ func NewBytePool(initialCapacity int) *Pool[bytes.Buffer] {
 
 // NewStringBuilderPool creates a new *Pool[strings.Builder] with the given initial capacity.
 // The builder will be automatically reset when returned to the pool.
+//
+// A capacity less than or equal to zero selects the current default capacity
+// (see SetDefaultCapacity).
 func NewStringBuilderPool(initialCapacity int) *Pool[strings.Builder] {
 	if initialCapacity <= 0 {
-		initialCapacity = DefaultCapacity
+		initialCapacity = defaultCapacity()
 	}
+	return newStringBuilderPool(func() int { return initialCapacity })
+}
+
+// newStringBuilderPool creates a string builder pool whose new builders have
+// the capacity reported by capacity at the time they are constructed.
+func newStringBuilderPool(capacity func() int) *Pool[strings.Builder] {
 	return New(
 		func() *strings.Builder {
 			var b strings.Builder
-			b.Grow(initialCapacity)
+			b.Grow(capacity())
 			return &b
 		},
 		func(b *strings.Builder) {
@@ -47,12 +65,19 @@ func NewStringBuilderPool(initialCapacity int) *Pool[strings.Builder] {
 // --- Global Pools ---
 
 // ByteBucket provides a ready-to-use global pool of *bytes.Buffer
-// with a default capacity of 4KB.
-var ByteBucket = NewBytePool(DefaultCapacity)
+// with the default capacity of 4KB (see SetDefaultCapacity).
+var ByteBucket = newBytePool(defaultCapacity)
 
 // StringBuilderBucket provides a ready-to-use global pool of *strings.Builder
-// with a default capacity of 4KB.
-var StringBuilderBucket = NewStringBuilderPool(DefaultCapacity)
+// with the default capacity of 4KB (see SetDefaultCapacity).
+var StringBuilderBucket = newStringBuilderPool(defaultCapacity)
+
+func init() {
+	RegisterDrainCallback(ByteBucket.Drain)
+	RegisterDrainCallback(StringBuilderBucket.Drain)
+	Registry.Register("ByteBucket", ByteBucket)
+	Registry.Register("StringBuilderBucket", StringBuilderBucket)
+}
 
 // --- Safe Callback Helpers ---
 
@@ -0,0 +1,37 @@
+package bucket
+
+import "sync"
+
+// drainCallbacks holds the callbacks invoked by DrainAll.
+var drainCallbacks struct {
+	sync.Mutex
+	fns []func()
+}
+
+// RegisterDrainCallback adds fn to the callbacks run by DrainAll.
+//
+// Register the Drain method of your own large pools so they are released
+// together with the built-in ones:
+//
+//	var imagePool = bucket.NewBytePool(1 << 20)
+//	func init() { bucket.RegisterDrainCallback(imagePool.Drain) }
+func RegisterDrainCallback(fn func()) {
+	drainCallbacks.Lock()
+	defer drainCallbacks.Unlock()
+	drainCallbacks.fns = append(drainCallbacks.fns, fn)
+}
+
+// DrainAll runs every registered drain callback, releasing pooled memory on
+// demand. Wire it to a memory-pressure signal, such as a watcher comparing
+// heap usage against a soft memory limit.
+//
+// The built-in global pools are registered automatically.
+func DrainAll() {
+	drainCallbacks.Lock()
+	fns := append([]func(){}, drainCallbacks.fns...)
+	drainCallbacks.Unlock()
+
+	for _, fn := range fns {
+		fn()
+	}
+}
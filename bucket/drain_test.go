@@ -0,0 +1,38 @@
+package bucket
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestPool_Drain verifies that Drain discards retained objects so the next Get constructs a new one.
+func TestPool_Drain(t *testing.T) {
+	var created int
+	pool := New(func() *int {
+		created++
+		v := created
+		return &v
+	}, func(v *int) {})
+
+	obj := pool.Get()
+	pool.Put(obj)
+	pool.Drain()
+
+	fresh := pool.Get()
+	assert.NotSame(t, obj, fresh, "Drained objects should not be handed out again")
+
+	pool.Put(fresh)
+	assert.NotPanics(t, func() { pool.Put(obj) }, "Objects checked out before Drain may still be returned")
+}
+
+// TestDrainAll verifies that registered callbacks are invoked.
+func TestDrainAll(t *testing.T) {
+	var calls int
+	RegisterDrainCallback(func() { calls++ })
+
+	DrainAll()
+	DrainAll()
+
+	assert.Equal(t, 2, calls)
+}
@@ -0,0 +1,72 @@
+package bucket
+
+import (
+	"encoding/json"
+	"io"
+)
+
+// rebindWriter forwards writes to a target that can be swapped between uses.
+// It lets a single *json.Encoder be reused across different destinations.
+type rebindWriter struct {
+	w io.Writer
+}
+
+// Write forwards p to the current target.
+func (r *rebindWriter) Write(p []byte) (int, error) {
+	return r.w.Write(p)
+}
+
+// JSONEncoder is a reusable *json.Encoder whose destination can be rebound.
+//
+// A json.Encoder is permanently tied to the writer it was created with, so it
+// normally has to be allocated per call. JSONEncoder routes its output through
+// an internal forwarding writer, allowing the same encoder to be drawn from a
+// pool and pointed at a new buffer each time.
+type JSONEncoder struct {
+	*json.Encoder
+	out *rebindWriter
+}
+
+// Bind points the encoder at w. Subsequent calls to Encode write to w.
+func (e *JSONEncoder) Bind(w io.Writer) {
+	e.out.w = w
+}
+
+// reset detaches the encoder from its destination and restores the default
+// encoding settings so no per-request configuration leaks into the next user.
+func (e *JSONEncoder) reset() {
+	e.out.w = nil
+	e.SetEscapeHTML(true)
+	e.SetIndent("", "")
+}
+
+// NewJSONEncoderPool creates a new *Pool[JSONEncoder].
+// Encoders are unbound and restored to default settings when returned.
+func NewJSONEncoderPool() *Pool[JSONEncoder] {
+	return New(
+		func() *JSONEncoder {
+			out := &rebindWriter{}
+			return &JSONEncoder{Encoder: json.NewEncoder(out), out: out}
+		},
+		func(e *JSONEncoder) {
+			e.reset()
+		},
+	)
+}
+
+// JSONEncoderBucket provides a ready-to-use global pool of *JSONEncoder.
+var JSONEncoderBucket = NewJSONEncoderPool()
+
+func init() {
+	RegisterDrainCallback(JSONEncoderBucket.Drain)
+}
+
+// WithJSONEncoder executes f with a pooled encoder bound to w.
+// The encoder is automatically unbound and returned to the pool after use.
+// Any error returned by f is propagated to the caller.
+func WithJSONEncoder(w io.Writer, f func(enc *JSONEncoder) error) error {
+	return JSONEncoderBucket.WithErr(func(enc *JSONEncoder) error {
+		enc.Bind(w)
+		return f(enc)
+	})
+}
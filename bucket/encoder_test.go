@@ -0,0 +1,50 @@
+package bucket
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestJSONEncoder_Rebind verifies that a pooled encoder writes only to the buffer it is bound to.
+func TestJSONEncoder_Rebind(t *testing.T) {
+	pool := NewJSONEncoderPool()
+	enc := pool.Get()
+
+	var first, second bytes.Buffer
+	enc.Bind(&first)
+	assert.NoError(t, enc.Encode(map[string]int{"a": 1}))
+	enc.Bind(&second)
+	assert.NoError(t, enc.Encode(map[string]int{"b": 2}))
+
+	assert.Equal(t, "{\"a\":1}\n", first.String())
+	assert.Equal(t, "{\"b\":2}\n", second.String())
+	pool.Put(enc)
+}
+
+// TestJSONEncoder_ResetSettings ensures encoder settings do not leak between pool users.
+func TestJSONEncoder_ResetSettings(t *testing.T) {
+	pool := NewJSONEncoderPool()
+	enc := pool.Get()
+
+	enc.SetIndent("", "  ")
+	enc.SetEscapeHTML(false)
+	enc.reset()
+
+	var buf bytes.Buffer
+	enc.Bind(&buf)
+	assert.NoError(t, enc.Encode(map[string]string{"html": "<b>"}))
+	assert.Equal(t, "{\"html\":\"\\u003cb\\u003e\"}\n", buf.String(), "Indentation and HTML escaping should be restored to defaults")
+}
+
+// TestWithJSONEncoder verifies the callback helper binds the encoder to the given writer.
+func TestWithJSONEncoder(t *testing.T) {
+	var buf bytes.Buffer
+	err := WithJSONEncoder(&buf, func(enc *JSONEncoder) error {
+		return enc.Encode([]int{1, 2, 3})
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "[1,2,3]\n", buf.String())
+}
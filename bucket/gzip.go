@@ -0,0 +1,76 @@
+package bucket
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+)
+
+// NewGzipWriterPool creates a new *Pool[gzip.Writer] using the given compression level.
+// Writers are detached from their destination when returned to the pool;
+// call Reset(dst) after Get to bind a writer to a new destination.
+//
+// Panics if level is not a valid gzip compression level.
+func NewGzipWriterPool(level int) *Pool[gzip.Writer] {
+	// Validate the level once up front so the constructor below cannot fail.
+	if _, err := gzip.NewWriterLevel(io.Discard, level); err != nil {
+		panic("bucket.NewGzipWriterPool: " + err.Error())
+	}
+	return New(
+		func() *gzip.Writer {
+			zw, _ := gzip.NewWriterLevel(io.Discard, level)
+			return zw
+		},
+		func(zw *gzip.Writer) {
+			zw.Reset(io.Discard)
+		},
+	)
+}
+
+// GzipWriterBucket provides a ready-to-use global pool of *gzip.Writer
+// using gzip.DefaultCompression.
+var GzipWriterBucket = NewGzipWriterPool(gzip.DefaultCompression)
+
+func init() {
+	RegisterDrainCallback(GzipWriterBucket.Drain)
+}
+
+// WithGzipJSON encodes data as JSON and gzips it, then calls f with the
+// compressed bytes. Any error from encoding, compression, or f is returned.
+//
+// It combines the pools in this package: the JSON is encoded with a pooled
+// JSONEncoder into a pooled buffer, then compressed by a writer from
+// GzipWriterBucket into a second pooled buffer. Both buffers are returned to
+// ByteBucket when f returns, so compressed must not be retained afterwards;
+// copy it (e.g. with bytes.Clone) to keep it, such as for a response cache.
+//
+// Example:
+//
+//	err := bucket.WithGzipJSON(catalog, func(compressed []byte) error {
+//		cache.Set("catalog", bytes.Clone(compressed))
+//		return nil
+//	})
+func WithGzipJSON(data any, f func(compressed []byte) error) error {
+	return WithByteBufferErr(func(raw *bytes.Buffer) error {
+		err := WithJSONEncoder(raw, func(enc *JSONEncoder) error {
+			return enc.Encode(data)
+		})
+		if err != nil {
+			return err
+		}
+
+		return WithByteBufferErr(func(compressed *bytes.Buffer) error {
+			err := GzipWriterBucket.WithErr(func(zw *gzip.Writer) error {
+				zw.Reset(compressed)
+				if _, err := zw.Write(raw.Bytes()); err != nil {
+					return err
+				}
+				return zw.Close()
+			})
+			if err != nil {
+				return err
+			}
+			return f(compressed.Bytes())
+		})
+	})
+}
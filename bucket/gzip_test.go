@@ -0,0 +1,44 @@
+package bucket
+
+import (
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestWithGzipJSON verifies that the compressed bytes decode back to the JSON encoding of the data.
+func TestWithGzipJSON(t *testing.T) {
+	data := map[string]string{"name": "Cassie"}
+
+	var stored []byte
+	err := WithGzipJSON(data, func(compressed []byte) error {
+		stored = bytes.Clone(compressed)
+		return nil
+	})
+	assert.NoError(t, err)
+
+	zr, err := gzip.NewReader(bytes.NewReader(stored))
+	assert.NoError(t, err)
+	plain, err := io.ReadAll(zr)
+	assert.NoError(t, err)
+	assert.Equal(t, "{\"name\":\"Cassie\"}\n", string(plain))
+}
+
+// TestWithGzipJSON_Errors ensures encoding errors skip f and errors from f are returned.
+func TestWithGzipJSON_Errors(t *testing.T) {
+	called := false
+	err := WithGzipJSON(make(chan int), func(compressed []byte) error {
+		called = true
+		return nil
+	})
+	assert.Error(t, err)
+	assert.False(t, called, "f should not run when encoding fails")
+
+	expectedErr := errors.New("cache full")
+	err = WithGzipJSON("ok", func(compressed []byte) error { return expectedErr })
+	assert.Equal(t, expectedErr, err)
+}
@@ -0,0 +1,120 @@
+package bucket
+
+import (
+	"sync"
+	"time"
+)
+
+// WithIdleTTL makes the pool drop objects that have sat unused for longer
+// than d, instead of retaining them until the garbage collector clears the
+// pool. Use it for expensive objects, such as gzip writers or large buffers,
+// that should not linger through low-traffic periods.
+//
+// Objects are timestamped when Put and kept in a pool-owned stack rather than
+// a sync.Pool; Get hands out the most recently returned object first, so
+// rarely needed extras age out. A background goroutine, started by the first
+// Put, checks for expired objects every d/2. Call Stop to end it once the
+// pool is no longer needed.
+//
+// Panics if d is less than or equal to zero.
+func WithIdleTTL[T any](d time.Duration) Option[T] {
+	if d <= 0 {
+		panic("bucket.WithIdleTTL: d must be greater than 0")
+	}
+
+	return func(p *Pool[T]) {
+		p.idle = &idleStore[T]{ttl: d, stop: make(chan struct{})}
+	}
+}
+
+// Stop halts the background eviction started by WithIdleTTL. The pool stays
+// usable, but idle objects are then only released by Drain. Stop is
+// idempotent and does nothing for pools without an idle TTL.
+func (p *Pool[T]) Stop() {
+	if p.idle != nil {
+		p.idle.stopOnce.Do(func() { close(p.idle.stop) })
+	}
+}
+
+// idleEntry is an object retained by an idleStore, with the time it was returned.
+type idleEntry[T any] struct {
+	obj   *T
+	since time.Time
+}
+
+// idleStore holds the objects of a pool created with WithIdleTTL.
+type idleStore[T any] struct {
+	ttl time.Duration
+
+	mu      sync.Mutex
+	entries []idleEntry[T] // Ordered from least to most recently returned.
+
+	startOnce sync.Once
+	stop      chan struct{}
+	stopOnce  sync.Once
+}
+
+// get removes and returns the most recently returned object, if any.
+func (s *idleStore[T]) get() (*T, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	n := len(s.entries)
+	if n == 0 {
+		return nil, false
+	}
+	obj := s.entries[n-1].obj
+	s.entries[n-1] = idleEntry[T]{}
+	s.entries = s.entries[:n-1]
+	return obj, true
+}
+
+// put retains obj, stamped with the current time, and starts the evictor
+// on first use.
+func (s *idleStore[T]) put(obj *T) {
+	s.startOnce.Do(func() { go s.run() })
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.entries = append(s.entries, idleEntry[T]{obj: obj, since: time.Now()})
+}
+
+// evict drops every object that has been idle for at least the TTL at now.
+func (s *idleStore[T]) evict(now time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	expired := 0
+	for expired < len(s.entries) && now.Sub(s.entries[expired].since) >= s.ttl {
+		expired++
+	}
+	if expired == 0 {
+		return
+	}
+	n := copy(s.entries, s.entries[expired:])
+	clear(s.entries[n:])
+	s.entries = s.entries[:n]
+}
+
+// drain drops every retained object.
+func (s *idleStore[T]) drain() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.entries = nil
+}
+
+// run evicts expired objects periodically until Stop is called. If Stop
+// was called before the first put, it returns right away.
+func (s *idleStore[T]) run() {
+	ticker := time.NewTicker(max(s.ttl/2, time.Millisecond))
+	defer ticker.Stop()
+
+	for {
+		select {
+		case now := <-ticker.C:
+			s.evict(now)
+		case <-s.stop:
+			return
+		}
+	}
+}
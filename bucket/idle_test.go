@@ -0,0 +1,112 @@
+package bucket
+
+import (
+	"runtime"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// newCountingPool returns an idle-TTL pool of ints along with a counter of constructed objects.
+func newCountingPool(ttl time.Duration) (*Pool[int], *atomic.Int32) {
+	var created atomic.Int32
+	pool := New(
+		func() *int {
+			created.Add(1)
+			return new(int)
+		},
+		func(n *int) { *n = 0 },
+		WithIdleTTL[int](ttl),
+	)
+	return pool, &created
+}
+
+// TestPool_WithIdleTTL_ReusesWithinTTL verifies that recently returned objects are reused, newest first.
+func TestPool_WithIdleTTL_ReusesWithinTTL(t *testing.T) {
+	pool, created := newCountingPool(time.Hour)
+	defer pool.Stop()
+
+	a, b := pool.Get(), pool.Get()
+	pool.Put(a)
+	pool.Put(b)
+
+	assert.Same(t, b, pool.Get(), "The most recently returned object should be handed out first")
+	assert.Same(t, a, pool.Get())
+	assert.Equal(t, int32(2), created.Load())
+}
+
+// TestPool_WithIdleTTL_Evicts verifies that objects idle longer than the TTL are dropped.
+func TestPool_WithIdleTTL_Evicts(t *testing.T) {
+	pool, created := newCountingPool(10 * time.Millisecond)
+	defer pool.Stop()
+
+	pool.Put(pool.Get())
+	assert.Eventually(t, func() bool {
+		pool.idle.mu.Lock()
+		defer pool.idle.mu.Unlock()
+		return len(pool.idle.entries) == 0
+	}, time.Second, time.Millisecond, "The idle object should be evicted")
+
+	pool.Get()
+	assert.Equal(t, int32(2), created.Load(), "Get should construct a new object after eviction")
+}
+
+// TestPool_WithIdleTTL_LazyEvictor verifies that idle pools skip the sync.Pool and start no goroutine before the first Put.
+func TestPool_WithIdleTTL_LazyEvictor(t *testing.T) {
+	const n = 50
+	before := runtime.NumGoroutine()
+
+	pools := make([]*Pool[int], n)
+	for i := range pools {
+		pools[i], _ = newCountingPool(time.Hour)
+		pools[i].Get()
+	}
+	assert.Nil(t, pools[0].pool.Load(), "Idle pools should not allocate a sync.Pool")
+	assert.Less(t, runtime.NumGoroutine()-before, n, "No evictor should start before the first Put")
+
+	for _, pool := range pools {
+		pool.Put(pool.Get())
+	}
+	assert.GreaterOrEqual(t, runtime.NumGoroutine()-before, n, "The first Put should start the evictor")
+
+	for _, pool := range pools {
+		pool.Stop()
+	}
+	assert.Eventually(t, func() bool {
+		return runtime.NumGoroutine()-before < n
+	}, time.Second, time.Millisecond, "Stop should end the evictors")
+}
+
+// TestPool_WithIdleTTL_Stop verifies that Stop halts eviction and is idempotent.
+func TestPool_WithIdleTTL_Stop(t *testing.T) {
+	pool, created := newCountingPool(5 * time.Millisecond)
+	pool.Stop()
+	pool.Stop()
+
+	obj := pool.Get()
+	pool.Put(obj)
+	time.Sleep(30 * time.Millisecond)
+
+	assert.Same(t, obj, pool.Get(), "Objects should no longer be evicted after Stop")
+	assert.Equal(t, int32(1), created.Load())
+
+	New(func() *int { return new(int) }, func(*int) {}).Stop() // No-op without a TTL.
+}
+
+// TestPool_WithIdleTTL_Drain verifies that Drain releases idle objects.
+func TestPool_WithIdleTTL_Drain(t *testing.T) {
+	pool, created := newCountingPool(time.Hour)
+	defer pool.Stop()
+
+	pool.Put(pool.Get())
+	pool.Drain()
+	pool.Get()
+	assert.Equal(t, int32(2), created.Load())
+}
+
+// TestWithIdleTTL_PanicsOnInvalidTTL verifies that a non-positive TTL is rejected.
+func TestWithIdleTTL_PanicsOnInvalidTTL(t *testing.T) {
+	assert.Panics(t, func() { WithIdleTTL[int](0) })
+}
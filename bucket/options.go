@@ -0,0 +1,51 @@
+package bucket
+
+// Option configures optional Pool behavior. Pass options to New.
+type Option[T any] func(*Pool[T])
+
+// WithOnGet registers fn to be called with every object handed out by Get
+// (and therefore Acquire, With, and WithErr).
+//
+// It is intended for instrumentation, e.g. counting checkouts in benchmarks.
+// Pools created without it pay nothing beyond a nil check. fn runs on the
+// caller's goroutine and must be safe for concurrent use.
+func WithOnGet[T any](fn func(obj *T)) Option[T] {
+	return func(p *Pool[T]) {
+		p.onGet = fn
+	}
+}
+
+// WithOnPut registers fn to be called with every object returned to the pool
+// by Put (and therefore Release, With, and WithErr), before it is reset, so
+// that fn can still observe its state, e.g. to record buffer sizes.
+//
+// Like WithOnGet, it costs nothing when unset, and fn must be safe for
+// concurrent use. Put ignores nil objects without calling fn.
+func WithOnPut[T any](fn func(obj *T)) Option[T] {
+	return func(p *Pool[T]) {
+		p.onPut = fn
+	}
+}
+
+// WithOnResetPanic registers fn to be called with the recovered value when
+// the pool's reset function panics during Put. The offending object is
+// dropped either way; fn lets the failure be logged or counted instead of
+// going unnoticed. fn must be safe for concurrent use.
+func WithOnResetPanic[T any](fn func(recovered any)) Option[T] {
+	return func(p *Pool[T]) {
+		p.onResetPanic = fn
+	}
+}
+
+// WithStats makes the pool count its gets, misses, puts, and discards, as
+// reported by Stats.
+//
+// Counting is off by default because every Get and Put then updates shared
+// atomic counters, which adds cross-core contention to hot pools. Pools
+// created without it pay nothing beyond a nil check. Use EnableStats for
+// pools that are already constructed.
+func WithStats[T any]() Option[T] {
+	return func(p *Pool[T]) {
+		p.stats.Store(new(poolCounters))
+	}
+}
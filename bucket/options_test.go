@@ -0,0 +1,60 @@
+package bucket
+
+import (
+	"bytes"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestPool_OnGetOnPut verifies that the instrumentation hooks observe every checkout and return.
+func TestPool_OnGetOnPut(t *testing.T) {
+	var gets, puts atomic.Int64
+	var lastSize int
+
+	pool := New(
+		func() *bytes.Buffer { return new(bytes.Buffer) },
+		func(b *bytes.Buffer) { b.Reset() },
+		WithOnGet(func(b *bytes.Buffer) { gets.Add(1) }),
+		WithOnPut(func(b *bytes.Buffer) {
+			puts.Add(1)
+			lastSize = b.Len()
+		}),
+	)
+
+	buf := pool.Get()
+	buf.WriteString("hello")
+	pool.Put(buf)
+
+	pool.With(func(b *bytes.Buffer) { b.WriteString("hi") })
+	pool.Put(nil)
+
+	assert.Equal(t, int64(2), gets.Load())
+	assert.Equal(t, int64(2), puts.Load(), "Nil objects should not reach the hook")
+	assert.Equal(t, 2, lastSize, "OnPut should run before the reset")
+}
+
+// BenchmarkPool_GetPut_NoHooks measures the baseline cost of a Get/Put round trip.
+func BenchmarkPool_GetPut_NoHooks(b *testing.B) {
+	pool := NewBytePool(DefaultCapacity)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		pool.Put(pool.Get())
+	}
+}
+
+// BenchmarkPool_GetPut_Hooks measures a Get/Put round trip with counting hooks installed.
+func BenchmarkPool_GetPut_Hooks(b *testing.B) {
+	var gets, puts atomic.Int64
+	pool := New(
+		func() *bytes.Buffer { return new(bytes.Buffer) },
+		func(b *bytes.Buffer) { b.Reset() },
+		WithOnGet(func(*bytes.Buffer) { gets.Add(1) }),
+		WithOnPut(func(*bytes.Buffer) { puts.Add(1) }),
+	)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		pool.Put(pool.Get())
+	}
+}
@@ -0,0 +1,53 @@
+package bucket
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestPool_AcquireRelease verifies that Acquire/Release behave like Get/Put, including the reset.
+func TestPool_AcquireRelease(t *testing.T) {
+	var resets int
+	pool := New(func() *[]int { return new([]int) }, func(s *[]int) {
+		resets++
+		*s = (*s)[:0]
+	})
+
+	obj := pool.Acquire()
+	*obj = append(*obj, 1, 2, 3)
+	pool.Release(obj)
+
+	assert.Equal(t, 1, resets, "Release should reset the object")
+	assert.Empty(t, *obj, "Released objects should be reset")
+
+	assert.NotPanics(t, func() { pool.Release(nil) }, "Releasing nil should be ignored like Put")
+}
+
+// TestPool_PutRecoversPanickingReset verifies that a panicking reset drops the object instead of crashing.
+func TestPool_PutRecoversPanickingReset(t *testing.T) {
+	type conn struct{ buf *[]byte }
+
+	var recovered any
+	var created int
+	pool := New(
+		func() *conn {
+			created++
+			return &conn{buf: new([]byte)}
+		},
+		func(c *conn) { *c.buf = (*c.buf)[:0] }, // panics if buf is nil
+		WithOnResetPanic[conn](func(r any) { recovered = r }),
+	)
+
+	bad := pool.Get()
+	bad.buf = nil
+
+	assert.NotPanics(t, func() { pool.Put(bad) }, "Put should recover from a panicking reset")
+	assert.NotNil(t, recovered, "The panic should be reported to the hook")
+
+	// The bad object must not be handed out again.
+	for i := 0; i < 10; i++ {
+		obj := pool.Get()
+		assert.NotNil(t, obj.buf)
+	}
+}
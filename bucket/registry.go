@@ -0,0 +1,70 @@
+package bucket
+
+import (
+	"maps"
+	"sync"
+)
+
+// StatsReporter is implemented by pools that can report their activity,
+// such as *Pool[T].
+type StatsReporter interface {
+	Stats() PoolStats
+}
+
+// PoolRegistry collects named pools so their statistics can be reported
+// together, e.g. from a debug endpoint. The zero value is ready to use.
+type PoolRegistry struct {
+	mu    sync.RWMutex
+	pools map[string]StatsReporter
+}
+
+// Registry is the process-wide PoolRegistry. ByteBucket and
+// StringBuilderBucket are registered automatically under their variable
+// names; like any pool, they report zero stats until counting is enabled
+// with EnableStats.
+//
+// Example:
+//
+//	var imagePool = bucket.New(newImage, resetImage, bucket.WithStats[Image]())
+//	func init() {
+//		bucket.Registry.Register("images", imagePool)
+//		bucket.ByteBucket.EnableStats()
+//	}
+var Registry = &PoolRegistry{}
+
+// Register adds p under name. It is safe to call concurrently, but is
+// typically done once during initialization.
+//
+// Panics if name is empty, p is nil, or name is already registered.
+func (r *PoolRegistry) Register(name string, p StatsReporter) {
+	if name == "" {
+		panic("bucket.PoolRegistry.Register: name must not be empty")
+	}
+	if p == nil {
+		panic("bucket.PoolRegistry.Register: pool must not be nil")
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.pools[name]; ok {
+		panic("bucket.PoolRegistry.Register: duplicate pool name " + name)
+	}
+	if r.pools == nil {
+		r.pools = make(map[string]StatsReporter)
+	}
+	r.pools[name] = p
+}
+
+// Stats returns a snapshot of every registered pool's statistics, keyed by
+// name.
+func (r *PoolRegistry) Stats() map[string]PoolStats {
+	r.mu.RLock()
+	pools := maps.Clone(r.pools)
+	r.mu.RUnlock()
+
+	stats := make(map[string]PoolStats, len(pools))
+	for name, p := range pools {
+		stats[name] = p.Stats()
+	}
+	return stats
+}
@@ -0,0 +1,98 @@
+package bucket
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestPool_Stats verifies that Get, Put, and discarded objects are counted.
+func TestPool_Stats(t *testing.T) {
+	pool := New(func() *[]int { return new([]int) }, func(s *[]int) { *s = (*s)[:0] }, WithStats[[]int]())
+
+	obj := pool.Get()
+	pool.Put(obj)
+	pool.Put(nil)
+
+	stats := pool.Stats()
+	assert.Equal(t, uint64(1), stats.Gets)
+	assert.Equal(t, uint64(1), stats.Misses, "The first Get must construct an object")
+	assert.Equal(t, uint64(1), stats.Puts, "Nil objects should not be counted")
+	assert.Equal(t, uint64(0), stats.Discards)
+
+	bad := New(func() *int { return new(int) }, func(*int) { panic("boom") }, WithStats[int]())
+	bad.Put(bad.Get())
+	assert.Equal(t, uint64(1), bad.Stats().Discards, "Objects with a panicking reset should be counted as discarded")
+	assert.Equal(t, uint64(0), bad.Stats().Puts)
+}
+
+// TestPool_StatsIdleTTL verifies that hits and misses are counted for pools with an idle TTL.
+func TestPool_StatsIdleTTL(t *testing.T) {
+	pool := New(func() *int { return new(int) }, func(*int) {}, WithIdleTTL[int](time.Hour), WithStats[int]())
+	defer pool.Stop()
+
+	pool.Put(pool.Get())
+	pool.Put(pool.Get())
+
+	stats := pool.Stats()
+	assert.Equal(t, uint64(2), stats.Gets)
+	assert.Equal(t, uint64(1), stats.Misses, "The second Get should reuse the returned object")
+	assert.Equal(t, 0.5, stats.HitRate())
+}
+
+// TestPool_StatsOptIn verifies that pools do not count unless stats are enabled.
+func TestPool_StatsOptIn(t *testing.T) {
+	pool := New(func() *int { return new(int) }, func(*int) {})
+	pool.Put(pool.Get())
+	assert.Equal(t, PoolStats{}, pool.Stats(), "Pools should not count by default")
+
+	pool.EnableStats()
+	pool.Put(pool.Get())
+	pool.EnableStats()
+	assert.Equal(t, uint64(1), pool.Stats().Gets, "Counting should start once enabled")
+	assert.Equal(t, uint64(1), pool.Stats().Puts, "Enabling again should not reset the counters")
+}
+
+// TestPoolStats_HitRate verifies the hit rate computation, including the no-Gets case.
+func TestPoolStats_HitRate(t *testing.T) {
+	assert.Equal(t, 0.0, PoolStats{}.HitRate())
+	assert.Equal(t, 0.75, PoolStats{Gets: 4, Misses: 1}.HitRate())
+	assert.Equal(t, 0.0, PoolStats{Gets: 2, Misses: 2}.HitRate())
+}
+
+// TestPoolRegistry verifies that registered pools are reported by name.
+func TestPoolRegistry(t *testing.T) {
+	var r PoolRegistry
+	assert.Empty(t, r.Stats(), "A zero registry should be usable and empty")
+
+	a := NewBytePool(64)
+	a.EnableStats()
+	b := NewStringBuilderPool(64)
+	r.Register("a", a)
+	r.Register("b", b)
+
+	a.Put(a.Get())
+
+	stats := r.Stats()
+	assert.Len(t, stats, 2)
+	assert.Equal(t, uint64(1), stats["a"].Gets)
+	assert.Equal(t, uint64(0), stats["b"].Gets)
+}
+
+// TestPoolRegistry_Panics verifies that invalid and duplicate registrations panic.
+func TestPoolRegistry_Panics(t *testing.T) {
+	var r PoolRegistry
+	r.Register("a", NewBytePool(64))
+
+	assert.Panics(t, func() { r.Register("", NewBytePool(64)) }, "An empty name should panic")
+	assert.Panics(t, func() { r.Register("b", nil) }, "A nil pool should panic")
+	assert.Panics(t, func() { r.Register("a", NewBytePool(64)) }, "A duplicate name should panic")
+}
+
+// TestRegistry_Globals verifies that the built-in global pools register themselves.
+func TestRegistry_Globals(t *testing.T) {
+	stats := Registry.Stats()
+	assert.Contains(t, stats, "ByteBucket")
+	assert.Contains(t, stats, "StringBuilderBucket")
+}
@@ -0,0 +1,69 @@
+package bucket
+
+import (
+	"bufio"
+	"io"
+)
+
+// NewScannerPool creates a new *Pool[bufio.Scanner].
+//
+// bufio.Scanner has no Reset method, so a scanner cannot be rebound to a new
+// reader in place. Scanners are cleared when returned to the pool, and after
+// Get callers must rebind them themselves. Rebinding replaces the whole
+// scanner, including its buffer, so pooled scanners carry no buffer of their
+// own; supply a reused one, e.g. from ByteBucket:
+//
+//	sc := pool.Get()
+//	defer pool.Put(sc)
+//	*sc = *bufio.NewScanner(r)
+//	sc.Buffer(buf, bufio.MaxScanTokenSize) // optional, supply a reused buffer
+//
+// WithScanner performs these steps with pooled buffers and is the preferred
+// way to borrow a scanner.
+func NewScannerPool() *Pool[bufio.Scanner] {
+	return New(
+		func() *bufio.Scanner {
+			return new(bufio.Scanner)
+		},
+		func(sc *bufio.Scanner) {
+			// Drop the reader and buffer so the pool does not keep them alive.
+			*sc = bufio.Scanner{}
+		},
+	)
+}
+
+// ScannerBucket provides a ready-to-use global pool of *bufio.Scanner.
+var ScannerBucket = NewScannerPool()
+
+func init() {
+	RegisterDrainCallback(ScannerBucket.Drain)
+}
+
+// WithScanner executes the given function f with a pooled *bufio.Scanner
+// from ScannerBucket reading from r. The scanner is automatically returned
+// to the pool after use, and any error returned by f is propagated.
+//
+// The scanner splits lines by default and reads into a pooled buffer from
+// ByteBucket, accepting tokens up to bufio.MaxScanTokenSize. Because the
+// buffer is reused, slices returned by sc.Bytes must not be retained after
+// f returns.
+//
+// Example:
+//
+//	err := bucket.WithScanner(r.Body, func(sc *bufio.Scanner) error {
+//		for sc.Scan() {
+//			process(sc.Bytes())
+//		}
+//		return sc.Err()
+//	})
+func WithScanner(r io.Reader, f func(sc *bufio.Scanner) error) error {
+	buf := ByteBucket.Get()
+	defer ByteBucket.Put(buf)
+
+	return ScannerBucket.WithErr(func(sc *bufio.Scanner) error {
+		*sc = *bufio.NewScanner(r)
+		scratch := buf.AvailableBuffer()
+		sc.Buffer(scratch[:cap(scratch)], bufio.MaxScanTokenSize)
+		return f(sc)
+	})
+}
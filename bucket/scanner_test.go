@@ -0,0 +1,77 @@
+package bucket
+
+import (
+	"bufio"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestWithScanner verifies that pooled scanners read lines from the given reader on every use.
+func TestWithScanner(t *testing.T) {
+	for _, input := range []string{"a\nb\nc\n", "x\ny"} {
+		var lines []string
+		err := WithScanner(strings.NewReader(input), func(sc *bufio.Scanner) error {
+			for sc.Scan() {
+				lines = append(lines, sc.Text())
+			}
+			return sc.Err()
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, strings.Split(strings.TrimSuffix(input, "\n"), "\n"), lines)
+	}
+}
+
+// TestWithScanner_LongLines verifies that tokens larger than the pooled buffer are still scanned.
+func TestWithScanner_LongLines(t *testing.T) {
+	long := strings.Repeat("z", 3*DefaultCapacity)
+
+	err := WithScanner(strings.NewReader(long+"\nshort\n"), func(sc *bufio.Scanner) error {
+		assert.True(t, sc.Scan())
+		assert.Equal(t, long, sc.Text())
+		assert.True(t, sc.Scan())
+		assert.Equal(t, "short", sc.Text())
+		return sc.Err()
+	})
+
+	assert.NoError(t, err)
+}
+
+// TestWithScanner_PropagatesError verifies that errors from f are returned.
+func TestWithScanner_PropagatesError(t *testing.T) {
+	sentinel := errors.New("stop")
+
+	err := WithScanner(strings.NewReader("a\n"), func(sc *bufio.Scanner) error {
+		return sentinel
+	})
+
+	assert.ErrorIs(t, err, sentinel)
+}
+
+// TestScannerPool_ManualRebind verifies the documented Get/rebind/Put pattern.
+func TestScannerPool_ManualRebind(t *testing.T) {
+	pool := NewScannerPool()
+
+	sc := pool.Get()
+	*sc = *bufio.NewScanner(strings.NewReader("one two"))
+	sc.Split(bufio.ScanWords)
+
+	var words []string
+	for sc.Scan() {
+		words = append(words, sc.Text())
+	}
+	pool.Put(sc)
+
+	assert.Equal(t, []string{"one", "two"}, words)
+	assert.Equal(t, bufio.Scanner{}, *sc, "Put should clear the scanner")
+}
+
+// TestScannerPool_NoBufferOnMiss verifies that new scanners do not allocate a buffer the first rebind would discard.
+func TestScannerPool_NoBufferOnMiss(t *testing.T) {
+	pool := NewScannerPool()
+
+	assert.Equal(t, bufio.Scanner{}, *pool.Get(), "A new scanner should be bare")
+}
@@ -0,0 +1,167 @@
+package helpers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"strconv"
+)
+
+// FieldError describes a request value that could not be bound to a struct field.
+type FieldError struct {
+	Field    string // Name of the request parameter (the tag value) or JSON field path.
+	Expected string // Expected Go type, e.g. "int" or "[]bool".
+	Value    string // Raw value received from the request.
+	Reason   string // Optional description used instead of Expected/Value, e.g. "unknown field".
+}
+
+// Error implements the error interface.
+func (e *FieldError) Error() string {
+	if e.Reason != "" {
+		return fmt.Sprintf("helpers: field %q: %s", e.Field, e.Reason)
+	}
+	return fmt.Sprintf("helpers: field %q: expected %s, got %q", e.Field, e.Expected, e.Value)
+}
+
+// message returns the short, client-facing description of the problem.
+func (e *FieldError) message() string {
+	if e.Reason != "" {
+		return e.Reason
+	}
+	return "expected " + e.Expected
+}
+
+// ValidationFields converts field errors into the map accepted by SendValidationError.
+func ValidationFields(errs []FieldError) map[string]string {
+	fields := make(map[string]string, len(errs))
+	for i := range errs {
+		fields[errs[i].Field] = errs[i].message()
+	}
+	return fields
+}
+
+// Bind populates dst from the request.
+//
+// dst must be a non-nil pointer to a struct. Bind first decodes the JSON
+// request body into dst (an empty body is allowed), then fills fields tagged
+// with `query:"name"` from URL query parameters and fields tagged with
+// `path:"name"` from the request's path values (see http.Request.PathValue).
+// Query and path values take precedence over the body.
+//
+// Supported field kinds are string, bool, signed and unsigned integers,
+// floats, and slices of those. Repeated query parameters populate slices.
+// A conversion failure is reported as a *FieldError.
+//
+// Example:
+//
+//	type ListParams struct {
+//		Org   string   `path:"org"`
+//		Page  int      `query:"page"`
+//		Tags  []string `query:"tag"`
+//	}
+//	var p ListParams
+//	if err := helpers.Bind(r, &p); err != nil {
+//		helpers.SendError(w, http.StatusBadRequest, err.Error())
+//		return
+//	}
+func Bind(r *http.Request, dst any) error {
+	rv := reflect.ValueOf(dst)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return errors.New("helpers: Bind destination must be a non-nil pointer to a struct")
+	}
+
+	if r.Body != nil && r.Body != http.NoBody && r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(dst); err != nil && !errors.Is(err, io.EOF) {
+			return fmt.Errorf("helpers: decode request body: %w", err)
+		}
+	}
+
+	query := r.URL.Query()
+	sv := rv.Elem()
+	st := sv.Type()
+
+	for i := 0; i < st.NumField(); i++ {
+		sf := st.Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+
+		var name string
+		var values []string
+		if tag := sf.Tag.Get("path"); tag != "" {
+			if v := r.PathValue(tag); v != "" {
+				name, values = tag, []string{v}
+			}
+		} else if tag := sf.Tag.Get("query"); tag != "" {
+			if v, ok := query[tag]; ok {
+				name, values = tag, v
+			}
+		}
+		if len(values) == 0 {
+			continue
+		}
+
+		if err := setField(sv.Field(i), name, values); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// setField converts raw request values into the field's type and assigns them.
+func setField(field reflect.Value, name string, values []string) error {
+	if field.Kind() == reflect.Slice {
+		slice := reflect.MakeSlice(field.Type(), len(values), len(values))
+		for i, v := range values {
+			if err := setScalar(slice.Index(i), v); err != nil {
+				return &FieldError{Field: name, Expected: field.Type().String(), Value: v}
+			}
+		}
+		field.Set(slice)
+		return nil
+	}
+
+	if err := setScalar(field, values[0]); err != nil {
+		return &FieldError{Field: name, Expected: field.Type().String(), Value: values[0]}
+	}
+	return nil
+}
+
+// setScalar parses a single string value into v according to its kind.
+func setScalar(v reflect.Value, raw string) error {
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(raw)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(raw)
+		if err != nil {
+			return err
+		}
+		v.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(raw, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(raw, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(raw, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetFloat(f)
+	default:
+		return fmt.Errorf("unsupported kind %s", v.Kind())
+	}
+	return nil
+}
@@ -0,0 +1,75 @@
+package helpers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type bindTarget struct {
+	Org    string   `path:"org"`
+	Name   string   `json:"name"`
+	Page   int      `query:"page"`
+	Active bool     `query:"active"`
+	Tags   []string `query:"tag"`
+	IDs    []uint   `query:"id"`
+}
+
+func TestBind(t *testing.T) {
+	req := httptest.NewRequest("POST", "/orgs/acme?page=3&active=true&tag=a&tag=b&id=1&id=2", strings.NewReader(`{"name":"Cassie"}`))
+	req.SetPathValue("org", "acme")
+
+	var dst bindTarget
+	err := Bind(req, &dst)
+
+	assert.NoError(t, err, "Bind should not fail")
+	assert.Equal(t, bindTarget{
+		Org:    "acme",
+		Name:   "Cassie",
+		Page:   3,
+		Active: true,
+		Tags:   []string{"a", "b"},
+		IDs:    []uint{1, 2},
+	}, dst)
+}
+
+func TestBind_EmptyBody(t *testing.T) {
+	req := httptest.NewRequest("GET", "/?page=2", nil)
+
+	var dst bindTarget
+	err := Bind(req, &dst)
+
+	assert.NoError(t, err, "An empty body should be allowed")
+	assert.Equal(t, 2, dst.Page)
+}
+
+func TestBind_FieldError(t *testing.T) {
+	req := httptest.NewRequest("GET", "/?page=abc", nil)
+
+	var dst bindTarget
+	err := Bind(req, &dst)
+
+	var fieldErr *FieldError
+	assert.ErrorAs(t, err, &fieldErr, "Conversion failures should be reported as *FieldError")
+	assert.Equal(t, "page", fieldErr.Field)
+	assert.Equal(t, "int", fieldErr.Expected)
+	assert.Equal(t, "abc", fieldErr.Value)
+}
+
+func TestBind_InvalidDestination(t *testing.T) {
+	req := httptest.NewRequest("GET", "/", nil)
+
+	var dst bindTarget
+	assert.Error(t, Bind(req, dst), "Non-pointer destinations should be rejected")
+	assert.Error(t, Bind(req, new(int)), "Non-struct destinations should be rejected")
+}
+
+func TestBind_MalformedBody(t *testing.T) {
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"name":`))
+
+	var dst bindTarget
+	assert.Error(t, Bind(req, &dst), "Malformed JSON should be reported")
+}
@@ -0,0 +1,71 @@
+package helpers
+
+import (
+	"bytes"
+	"compress/gzip"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/iameggi/cassie/bucket"
+)
+
+// compressThreshold is the minimum encoded body size, in bytes, worth gzipping.
+// Below it the gzip framing overhead outweighs the savings.
+const compressThreshold = 1024
+
+// SendJSONCompressed behaves like SendJSON, but gzips the body when the
+// request's Accept-Encoding allows it and the encoded payload is at least
+// 1KB. Smaller payloads are sent uncompressed.
+//
+// Compression uses a pooled *gzip.Writer and a second pooled buffer.
+// "Vary: Accept-Encoding" is always set so caches keep both variants apart.
+// HEAD requests are handled as in SendJSONRequest.
+func SendJSONCompressed(w http.ResponseWriter, r *http.Request, statusCode int, data interface{}) error {
+	_, err := sendJSON(w, statusCode, data, jsonConfig{request: r, compress: true})
+	return err
+}
+
+// acceptsGzip reports whether the request's Accept-Encoding header allows gzip.
+//
+// An explicit gzip entry takes precedence over the "*" wildcard, which is
+// only consulted when gzip is not listed. Either is refused by q=0.
+func acceptsGzip(r *http.Request) bool {
+	wildcard := false
+	for _, header := range r.Header.Values("Accept-Encoding") {
+		for _, part := range strings.Split(header, ",") {
+			coding, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+			coding = strings.TrimSpace(coding)
+
+			switch {
+			case strings.EqualFold(coding, "gzip"):
+				return acceptableQ(params)
+			case coding == "*":
+				wildcard = acceptableQ(params)
+			}
+		}
+	}
+	return wildcard
+}
+
+// acceptableQ reports whether the parameters of an Accept-Encoding entry
+// leave it acceptable: an explicit q=0 means "not acceptable".
+func acceptableQ(params string) bool {
+	if q, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+		if v, err := strconv.ParseFloat(q, 64); err == nil && v == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// gzipInto compresses src into dst using a pooled gzip writer.
+func gzipInto(dst *bytes.Buffer, src []byte) error {
+	return bucket.GzipWriterBucket.WithErr(func(zw *gzip.Writer) error {
+		zw.Reset(dst)
+		if _, err := zw.Write(src); err != nil {
+			return err
+		}
+		return zw.Close()
+	})
+}
@@ -0,0 +1,98 @@
+package helpers
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSendJSONCompressed(t *testing.T) {
+	data := map[string]string{"blob": strings.Repeat("cassie ", 500)}
+
+	req := httptest.NewRequest("GET", "/", nil)
+	req.Header.Set("Accept-Encoding", "br, gzip;q=0.8")
+	rr := httptest.NewRecorder()
+
+	err := SendJSONCompressed(rr, req, http.StatusOK, data)
+
+	assert.NoError(t, err, "SendJSONCompressed should not fail")
+	assert.Equal(t, "gzip", rr.Header().Get("Content-Encoding"), "Large payloads should be gzipped")
+	assert.Equal(t, "Accept-Encoding", rr.Header().Get("Vary"))
+	assert.Equal(t, strconv.Itoa(rr.Body.Len()), rr.Header().Get("Content-Length"), "Content-Length should be the compressed size")
+
+	zr, err := gzip.NewReader(rr.Body)
+	assert.NoError(t, err, "Body should be valid gzip")
+	raw, err := io.ReadAll(zr)
+	assert.NoError(t, err)
+
+	var decoded map[string]string
+	assert.NoError(t, json.Unmarshal(raw, &decoded))
+	assert.Equal(t, data, decoded, "Decompressed body does not match input data")
+}
+
+func TestSendJSONCompressed_SmallPayload(t *testing.T) {
+	req := httptest.NewRequest("GET", "/", nil)
+	req.Header.Set("Accept-Encoding", "gzip")
+	rr := httptest.NewRecorder()
+
+	assert.NoError(t, SendJSONCompressed(rr, req, http.StatusOK, map[string]int{"id": 1}))
+
+	assert.Empty(t, rr.Header().Get("Content-Encoding"), "Small payloads should be sent uncompressed")
+	assert.Equal(t, strconv.Itoa(rr.Body.Len()), rr.Header().Get("Content-Length"), "Uncompressed responses should set Content-Length")
+	assert.JSONEq(t, `{"id":1}`, rr.Body.String())
+}
+
+func TestSendJSONCompressed_NotAccepted(t *testing.T) {
+	data := map[string]string{"blob": strings.Repeat("cassie ", 500)}
+
+	for _, header := range []string{"", "identity", "gzip;q=0", "*, gzip;q=0", "*;q=0"} {
+		req := httptest.NewRequest("GET", "/", nil)
+		req.Header.Set("Accept-Encoding", header)
+		rr := httptest.NewRecorder()
+
+		assert.NoError(t, SendJSONCompressed(rr, req, http.StatusOK, data))
+		assert.Empty(t, rr.Header().Get("Content-Encoding"), "Accept-Encoding %q should not allow gzip", header)
+	}
+}
+
+func TestAcceptsGzip(t *testing.T) {
+	cases := map[string]bool{
+		"gzip":            true,
+		"*":               true,
+		"GZIP":            true,
+		"*;q=0, gzip":     true,
+		"gzip, *;q=0":     true,
+		"*, gzip;q=0":     false,
+		"br, *;q=0":       false,
+		"br;q=1, *;q=0.5": true,
+		"identity":        false,
+	}
+
+	for header, expected := range cases {
+		req := httptest.NewRequest("GET", "/", nil)
+		req.Header.Set("Accept-Encoding", header)
+		assert.Equal(t, expected, acceptsGzip(req), "Accept-Encoding %q", header)
+	}
+}
+
+func TestSendJSONCompressed_HeadMatchesGet(t *testing.T) {
+	data := map[string]string{"blob": strings.Repeat("cassie ", 500)}
+
+	headers := map[string]http.Header{}
+	for _, method := range []string{"GET", "HEAD"} {
+		req := httptest.NewRequest(method, "/", nil)
+		req.Header.Set("Accept-Encoding", "gzip")
+		rr := httptest.NewRecorder()
+		assert.NoError(t, SendJSONCompressed(rr, req, http.StatusOK, data))
+		headers[method] = rr.Header()
+	}
+
+	assert.Equal(t, headers["GET"], headers["HEAD"], "HEAD should carry the headers of the equivalent GET")
+}
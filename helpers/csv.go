@@ -0,0 +1,88 @@
+package helpers
+
+import (
+	"bytes"
+	"encoding/csv"
+	"iter"
+	"mime"
+	"net/http"
+
+	"github.com/iameggi/cassie/bucket"
+)
+
+// SendCSV writes rows as a downloadable CSV attachment using Cassie's pooled buffers.
+//
+// The whole document is encoded into a pooled *bytes.Buffer before anything is
+// sent, so an encoding failure can still be reported with a 500. Quoting and
+// escaping follow RFC 4180 via encoding/csv.
+//
+// Returns an error if encoding or writing to the client fails.
+func SendCSV(w http.ResponseWriter, filename string, rows [][]string) error {
+	return bucket.WithByteBufferErr(func(buf *bytes.Buffer) error {
+		cw := csv.NewWriter(buf)
+		if err := cw.WriteAll(rows); err != nil {
+			http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+			return err
+		}
+
+		setCSVHeaders(w, filename)
+		w.WriteHeader(http.StatusOK)
+
+		if _, err := w.Write(buf.Bytes()); err != nil {
+			return err
+		}
+		return nil
+	})
+}
+
+// StreamCSV writes rows as a downloadable CSV attachment without buffering the
+// whole document.
+//
+// Rows are encoded into a pooled *bytes.Buffer that is written and flushed to
+// the client every few kilobytes, keeping memory bounded for large exports.
+// Since the status is committed before the first row, mid-stream failures can
+// only be returned to the caller.
+func StreamCSV(w http.ResponseWriter, filename string, rows iter.Seq[[]string]) error {
+	flusher, _ := w.(http.Flusher)
+
+	return bucket.WithByteBufferErr(func(buf *bytes.Buffer) error {
+		setCSVHeaders(w, filename)
+		w.WriteHeader(http.StatusOK)
+
+		cw := csv.NewWriter(buf)
+
+		// flush moves encoded rows from the pooled buffer to the client.
+		flush := func() error {
+			cw.Flush()
+			if err := cw.Error(); err != nil {
+				return err
+			}
+			if _, err := w.Write(buf.Bytes()); err != nil {
+				return err
+			}
+			buf.Reset()
+			if flusher != nil {
+				flusher.Flush()
+			}
+			return nil
+		}
+
+		for row := range rows {
+			if err := cw.Write(row); err != nil {
+				return err
+			}
+			if buf.Len() >= streamFlushThreshold {
+				if err := flush(); err != nil {
+					return err
+				}
+			}
+		}
+		return flush()
+	})
+}
+
+// setCSVHeaders sets the content type and attachment disposition for CSV downloads.
+func setCSVHeaders(w http.ResponseWriter, filename string) {
+	w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": filename}))
+}
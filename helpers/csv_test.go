@@ -0,0 +1,53 @@
+package helpers
+
+import (
+	"encoding/csv"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSendCSV(t *testing.T) {
+	rows := [][]string{
+		{"id", "name"},
+		{"1", `Cassie "the toolkit"`},
+		{"2", "comma, inside"},
+	}
+
+	rr := httptest.NewRecorder()
+
+	err := SendCSV(rr, "users.csv", rows)
+
+	assert.NoError(t, err, "SendCSV should not fail")
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, "text/csv; charset=utf-8", rr.Header().Get("Content-Type"), "Incorrect Content-Type header")
+	assert.Equal(t, `attachment; filename=users.csv`, rr.Header().Get("Content-Disposition"), "Incorrect Content-Disposition header")
+
+	parsed, err := csv.NewReader(strings.NewReader(rr.Body.String())).ReadAll()
+	assert.NoError(t, err, "Response body should be valid CSV")
+	assert.Equal(t, rows, parsed, "Quoting and escaping should round-trip")
+}
+
+func TestStreamCSV(t *testing.T) {
+	rows := make([][]string, 2000)
+	for i := range rows {
+		rows[i] = []string{strconv.Itoa(i), "row " + strconv.Itoa(i)}
+	}
+
+	rr := httptest.NewRecorder()
+
+	err := StreamCSV(rr, "export.csv", slices.Values(rows))
+
+	assert.NoError(t, err, "StreamCSV should not fail")
+	assert.True(t, rr.Flushed, "Large exports should be flushed while streaming")
+	assert.Contains(t, rr.Header().Get("Content-Disposition"), "attachment")
+
+	parsed, err := csv.NewReader(strings.NewReader(rr.Body.String())).ReadAll()
+	assert.NoError(t, err)
+	assert.Equal(t, rows, parsed)
+}
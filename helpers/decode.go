@@ -0,0 +1,266 @@
+package helpers
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/iameggi/cassie/bucket"
+)
+
+var (
+	// ErrEmptyBody is returned by DecodeJSON when the request body is empty.
+	ErrEmptyBody = errors.New("helpers: request body is empty")
+
+	// ErrTrailingData is returned by DecodeJSON when the request body contains
+	// more than a single JSON value.
+	ErrTrailingData = errors.New("helpers: request body contains trailing data after JSON value")
+)
+
+// DecodeJSON decodes exactly one JSON value from the request body into dst.
+//
+// It returns ErrEmptyBody if the body is missing or empty, and ErrTrailingData
+// if anything other than whitespace follows the first JSON value. Both can be
+// checked with errors.Is and mapped to a 400 response with a precise message.
+//
+// If the body was wrapped with http.MaxBytesReader and exceeds its limit, an
+// error wrapping ErrBodyTooLarge (and mentioning the limit) is returned
+// instead, so the caller can respond 413 rather than 400.
+// Any other decoding error is returned as-is.
+func DecodeJSON(r *http.Request, dst any) error {
+	if r.Body == nil || r.Body == http.NoBody {
+		return ErrEmptyBody
+	}
+
+	dec := json.NewDecoder(r.Body)
+	if err := dec.Decode(dst); err != nil {
+		if errors.Is(err, io.EOF) {
+			return ErrEmptyBody
+		}
+		return bodyTooLarge(err)
+	}
+
+	// A second Decode must hit EOF; anything else means extra data was sent.
+	if err := dec.Decode(&struct{}{}); !errors.Is(err, io.EOF) {
+		var maxErr *http.MaxBytesError
+		if errors.As(err, &maxErr) {
+			return bodyTooLarge(err)
+		}
+		return ErrTrailingData
+	}
+	return nil
+}
+
+// bodyTooLarge translates the error reported by an http.MaxBytesReader into
+// one wrapping ErrBodyTooLarge. Other errors are returned unchanged.
+func bodyTooLarge(err error) error {
+	var maxErr *http.MaxBytesError
+	if errors.As(err, &maxErr) {
+		return fmt.Errorf("%w: limit is %d bytes", ErrBodyTooLarge, maxErr.Limit)
+	}
+	return err
+}
+
+// DecodeWithDefaults fills dst from `default:"..."` struct tags and then
+// decodes the JSON request body over it, so fields present in the body win.
+//
+// dst must be a non-nil pointer to a struct. Defaults apply to string, bool,
+// integer, and float fields, including those of nested structs. An empty body
+// is not an error: dst simply keeps its defaults. Trailing data and malformed
+// JSON are reported as with DecodeJSON.
+//
+// Example:
+//
+//	type ListRequest struct {
+//		Limit int    `json:"limit" default:"10"`
+//		Sort  string `json:"sort" default:"created_at"`
+//	}
+func DecodeWithDefaults(r *http.Request, dst any) error {
+	rv := reflect.ValueOf(dst)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return errors.New("helpers: DecodeWithDefaults destination must be a non-nil pointer to a struct")
+	}
+
+	if err := applyDefaults(rv.Elem()); err != nil {
+		return err
+	}
+
+	if err := DecodeJSON(r, dst); err != nil && !errors.Is(err, ErrEmptyBody) {
+		return err
+	}
+	return nil
+}
+
+// applyDefaults sets every exported field of sv that carries a default tag.
+func applyDefaults(sv reflect.Value) error {
+	st := sv.Type()
+	for i := 0; i < st.NumField(); i++ {
+		sf := st.Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+
+		field := sv.Field(i)
+		if tag, ok := sf.Tag.Lookup("default"); ok {
+			if err := setScalar(field, tag); err != nil {
+				return fmt.Errorf("helpers: invalid default %q for field %s: %w", tag, sf.Name, err)
+			}
+			continue
+		}
+		if field.Kind() == reflect.Struct {
+			if err := applyDefaults(field); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// DecodeJSONDetailed decodes a single JSON object from the request body into
+// dst and reports every problematic field it can find, rather than stopping
+// at the first one.
+//
+// dst should be a pointer to a struct. Body-level problems (ErrEmptyBody,
+// ErrTrailingData, ErrBodyTooLarge, malformed JSON, a non-object payload) are
+// returned as err.
+// Field-level problems are returned as fieldErrs, one per field: keys with no
+// matching struct field are reported with the reason "unknown field", and
+// type mismatches carry the field path, expected type, and received JSON
+// kind, as reported by *json.UnmarshalTypeError.
+//
+// Detection is best-effort: encoding/json reports one error at a time, so
+// each top-level field is checked independently, and within a nested value
+// only the first mismatch is reported. dst is populated only when both
+// return values are empty.
+//
+// The result feeds directly into SendValidationError:
+//
+//	fieldErrs, err := helpers.DecodeJSONDetailed(r, &in)
+//	if err != nil {
+//		helpers.SendErr(w, err)
+//		return
+//	}
+//	if len(fieldErrs) > 0 {
+//		helpers.SendValidationError(w, helpers.ValidationFields(fieldErrs))
+//		return
+//	}
+func DecodeJSONDetailed(r *http.Request, dst any) (fieldErrs []FieldError, err error) {
+	rv := reflect.ValueOf(dst)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return nil, errors.New("helpers: DecodeJSONDetailed destination must be a non-nil pointer to a struct")
+	}
+	if r.Body == nil || r.Body == http.NoBody {
+		return nil, ErrEmptyBody
+	}
+
+	err = bucket.WithByteBufferErr(func(buf *bytes.Buffer) error {
+		if _, err := buf.ReadFrom(r.Body); err != nil {
+			return bodyTooLarge(err)
+		}
+
+		// Split the object into its top-level members, enforcing a single value.
+		var members map[string]json.RawMessage
+		dec := json.NewDecoder(bytes.NewReader(buf.Bytes()))
+		if err := dec.Decode(&members); err != nil {
+			if errors.Is(err, io.EOF) {
+				return ErrEmptyBody
+			}
+			return err
+		}
+		if err := dec.Decode(&struct{}{}); !errors.Is(err, io.EOF) {
+			return ErrTrailingData
+		}
+
+		fieldErrs = checkJSONFields(rv.Elem().Type(), members)
+		if len(fieldErrs) > 0 {
+			return nil
+		}
+		return json.Unmarshal(buf.Bytes(), dst)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return fieldErrs, nil
+}
+
+// checkJSONFields validates each JSON member against the matching field of st.
+// Results are sorted by field name for deterministic output.
+func checkJSONFields(st reflect.Type, members map[string]json.RawMessage) []FieldError {
+	fields := jsonFields(st)
+
+	keys := make([]string, 0, len(members))
+	for key := range members {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var fieldErrs []FieldError
+	for _, key := range keys {
+		ft, ok := lookupJSONField(fields, key)
+		if !ok {
+			fieldErrs = append(fieldErrs, FieldError{Field: key, Reason: "unknown field"})
+			continue
+		}
+
+		var typeErr *json.UnmarshalTypeError
+		if err := json.Unmarshal(members[key], reflect.New(ft).Interface()); errors.As(err, &typeErr) {
+			path := key
+			if typeErr.Field != "" {
+				path += "." + typeErr.Field
+			}
+			fieldErrs = append(fieldErrs, FieldError{Field: path, Expected: typeErr.Type.String(), Value: typeErr.Value})
+		}
+	}
+	return fieldErrs
+}
+
+// jsonFields maps the JSON names of st's exported fields to their types,
+// following encoding/json's tag rules and flattening untagged embedded structs.
+func jsonFields(st reflect.Type) map[string]reflect.Type {
+	fields := make(map[string]reflect.Type, st.NumField())
+	for i := 0; i < st.NumField(); i++ {
+		sf := st.Field(i)
+		tag := sf.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, _, _ := strings.Cut(tag, ",")
+
+		if sf.Anonymous && name == "" && sf.Type.Kind() == reflect.Struct {
+			for k, v := range jsonFields(sf.Type) {
+				if _, exists := fields[k]; !exists {
+					fields[k] = v
+				}
+			}
+			continue
+		}
+		if !sf.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = sf.Name
+		}
+		fields[name] = sf.Type
+	}
+	return fields
+}
+
+// lookupJSONField finds key among fields, preferring an exact match and
+// falling back to the case-insensitive matching used by encoding/json.
+func lookupJSONField(fields map[string]reflect.Type, key string) (reflect.Type, bool) {
+	if ft, ok := fields[key]; ok {
+		return ft, true
+	}
+	for name, ft := range fields {
+		if strings.EqualFold(name, key) {
+			return ft, true
+		}
+	}
+	return nil, false
+}
@@ -0,0 +1,173 @@
+package helpers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDecodeJSON(t *testing.T) {
+	req := httptest.NewRequest("POST", "/", strings.NewReader(`{"name":"Cassie"}`))
+
+	var dst struct {
+		Name string `json:"name"`
+	}
+	err := DecodeJSON(req, &dst)
+
+	assert.NoError(t, err, "DecodeJSON should not fail")
+	assert.Equal(t, "Cassie", dst.Name)
+}
+
+func TestDecodeJSON_EmptyBody(t *testing.T) {
+	var dst map[string]any
+
+	req := httptest.NewRequest("POST", "/", nil)
+	assert.ErrorIs(t, DecodeJSON(req, &dst), ErrEmptyBody, "Missing body should return ErrEmptyBody")
+
+	req = httptest.NewRequest("POST", "/", strings.NewReader("  \n"))
+	assert.ErrorIs(t, DecodeJSON(req, &dst), ErrEmptyBody, "Whitespace-only body should return ErrEmptyBody")
+}
+
+func TestDecodeJSON_TrailingData(t *testing.T) {
+	var dst map[string]any
+
+	req := httptest.NewRequest("POST", "/", strings.NewReader(`{"a":1}{"b":2}`))
+	assert.ErrorIs(t, DecodeJSON(req, &dst), ErrTrailingData, "Multiple JSON values should return ErrTrailingData")
+
+	req = httptest.NewRequest("POST", "/", strings.NewReader(`{"a":1} garbage`))
+	assert.ErrorIs(t, DecodeJSON(req, &dst), ErrTrailingData, "Garbage after the value should return ErrTrailingData")
+
+	req = httptest.NewRequest("POST", "/", strings.NewReader("{\"a\":1}\n"))
+	assert.NoError(t, DecodeJSON(req, &dst), "Trailing whitespace should be accepted")
+}
+
+func TestDecodeJSON_Malformed(t *testing.T) {
+	var dst map[string]any
+
+	req := httptest.NewRequest("POST", "/", strings.NewReader(`{"a":`))
+	err := DecodeJSON(req, &dst)
+
+	assert.Error(t, err)
+	assert.NotErrorIs(t, err, ErrEmptyBody)
+	assert.NotErrorIs(t, err, ErrTrailingData)
+}
+
+func TestDecodeJSON_BodyTooLarge(t *testing.T) {
+	var dst map[string]any
+
+	rr := httptest.NewRecorder()
+	req := httptest.NewRequest("POST", "/", strings.NewReader(`{"name":"`+strings.Repeat("x", 64)+`"}`))
+	req.Body = http.MaxBytesReader(rr, req.Body, 16)
+
+	err := DecodeJSON(req, &dst)
+	assert.ErrorIs(t, err, ErrBodyTooLarge, "Oversized bodies should return ErrBodyTooLarge")
+	assert.Contains(t, err.Error(), "16 bytes", "The error should mention the configured limit")
+
+	// The limit may also trip while checking for trailing data.
+	req = httptest.NewRequest("POST", "/", strings.NewReader(`{}`+strings.Repeat(" ", 64)))
+	req.Body = http.MaxBytesReader(rr, req.Body, 16)
+	assert.ErrorIs(t, DecodeJSON(req, &dst), ErrBodyTooLarge)
+}
+
+func TestDecodeWithDefaults(t *testing.T) {
+	type options struct {
+		Verbose bool `json:"verbose" default:"true"`
+	}
+	type listRequest struct {
+		Limit   int     `json:"limit" default:"10"`
+		Sort    string  `json:"sort" default:"created_at"`
+		Ratio   float64 `json:"ratio" default:"0.5"`
+		Options options `json:"options"`
+	}
+
+	req := httptest.NewRequest("POST", "/", strings.NewReader(`{"sort":"name"}`))
+	var dst listRequest
+	err := DecodeWithDefaults(req, &dst)
+
+	assert.NoError(t, err, "DecodeWithDefaults should not fail")
+	assert.Equal(t, listRequest{Limit: 10, Sort: "name", Ratio: 0.5, Options: options{Verbose: true}}, dst, "Body values should override defaults")
+
+	req = httptest.NewRequest("POST", "/", nil)
+	dst = listRequest{}
+	assert.NoError(t, DecodeWithDefaults(req, &dst), "An empty body should keep the defaults")
+	assert.Equal(t, 10, dst.Limit)
+
+	req = httptest.NewRequest("POST", "/", strings.NewReader(`{"limit":0}`))
+	dst = listRequest{}
+	assert.NoError(t, DecodeWithDefaults(req, &dst))
+	assert.Equal(t, 0, dst.Limit, "Explicit zero values in the body should win")
+}
+
+func TestDecodeWithDefaults_InvalidTag(t *testing.T) {
+	var dst struct {
+		Limit int `default:"ten"`
+	}
+	req := httptest.NewRequest("POST", "/", nil)
+
+	assert.Error(t, DecodeWithDefaults(req, &dst), "Unparseable defaults should be reported")
+}
+
+type detailedAddress struct {
+	Zip int `json:"zip"`
+}
+
+type detailedTarget struct {
+	Name    string          `json:"name"`
+	Age     int             `json:"age"`
+	Admin   bool            `json:"admin"`
+	Address detailedAddress `json:"address"`
+}
+
+func TestDecodeJSONDetailed(t *testing.T) {
+	req := httptest.NewRequest("POST", "/", strings.NewReader(`{"name":"Cassie","age":3,"address":{"zip":12345}}`))
+
+	var dst detailedTarget
+	fieldErrs, err := DecodeJSONDetailed(req, &dst)
+
+	assert.NoError(t, err)
+	assert.Empty(t, fieldErrs)
+	assert.Equal(t, detailedTarget{Name: "Cassie", Age: 3, Address: detailedAddress{Zip: 12345}}, dst)
+}
+
+func TestDecodeJSONDetailed_FieldErrors(t *testing.T) {
+	body := `{"name":1,"age":"old","admin":true,"nickname":"c","address":{"zip":"abc"}}`
+	req := httptest.NewRequest("POST", "/", strings.NewReader(body))
+
+	var dst detailedTarget
+	fieldErrs, err := DecodeJSONDetailed(req, &dst)
+
+	assert.NoError(t, err, "Field problems should not be reported as a body error")
+	assert.Equal(t, []FieldError{
+		{Field: "address.zip", Expected: "int", Value: "string"},
+		{Field: "age", Expected: "int", Value: "string"},
+		{Field: "name", Expected: "string", Value: "number"},
+		{Field: "nickname", Reason: "unknown field"},
+	}, fieldErrs, "Every problematic field should be reported")
+	assert.Equal(t, detailedTarget{}, dst, "dst should not be populated when fields are invalid")
+
+	assert.Equal(t, map[string]string{
+		"address.zip": "expected int",
+		"age":         "expected int",
+		"name":        "expected string",
+		"nickname":    "unknown field",
+	}, ValidationFields(fieldErrs))
+}
+
+func TestDecodeJSONDetailed_BodyErrors(t *testing.T) {
+	var dst detailedTarget
+
+	_, err := DecodeJSONDetailed(httptest.NewRequest("POST", "/", nil), &dst)
+	assert.ErrorIs(t, err, ErrEmptyBody)
+
+	_, err = DecodeJSONDetailed(httptest.NewRequest("POST", "/", strings.NewReader(`{}{}`)), &dst)
+	assert.ErrorIs(t, err, ErrTrailingData)
+
+	_, err = DecodeJSONDetailed(httptest.NewRequest("POST", "/", strings.NewReader(`{"name":`)), &dst)
+	assert.Error(t, err, "Malformed JSON should be a body error")
+
+	_, err = DecodeJSONDetailed(httptest.NewRequest("POST", "/", strings.NewReader(`[1,2]`)), &dst)
+	assert.Error(t, err, "Non-object payloads should be a body error")
+}
@@ -0,0 +1,158 @@
+package helpers
+
+import (
+	"errors"
+	"net/http"
+	"sync"
+)
+
+// errorMapping associates a matcher with the HTTP status it maps to.
+type errorMapping struct {
+	match  func(error) bool
+	status int
+}
+
+// errorRegistry holds the mappings consulted by SendErr.
+var errorRegistry = struct {
+	sync.RWMutex
+	mappings []errorMapping
+}{
+	mappings: []errorMapping{
+		{match: func(err error) bool { return errors.Is(err, ErrEmptyBody) }, status: http.StatusBadRequest},
+		{match: func(err error) bool { return errors.Is(err, ErrTrailingData) }, status: http.StatusBadRequest},
+		{match: func(err error) bool { return errors.Is(err, ErrMissingQuery) }, status: http.StatusBadRequest},
+		{match: func(err error) bool { var fe *FieldError; return errors.As(err, &fe) }, status: http.StatusBadRequest},
+		{match: func(err error) bool { return errors.Is(err, ErrBodyTooLarge) }, status: http.StatusRequestEntityTooLarge},
+	},
+}
+
+// RegisterErrorMapping maps errors matching target (via errors.Is) to statusCode
+// for use by SendErr.
+//
+// Mappings are consulted in reverse registration order, so a later
+// registration overrides an earlier one for the same error. It is safe to
+// call concurrently, but is typically done once during initialization.
+func RegisterErrorMapping(target error, statusCode int) {
+	registerErrorMatcher(func(err error) bool { return errors.Is(err, target) }, statusCode)
+}
+
+// RegisterErrorType maps any error in the chain of type T (via errors.As)
+// to statusCode for use by SendErr.
+//
+// Example:
+//
+//	helpers.RegisterErrorType[*store.ConflictError](http.StatusConflict)
+func RegisterErrorType[T error](statusCode int) {
+	registerErrorMatcher(func(err error) bool { var target T; return errors.As(err, &target) }, statusCode)
+}
+
+// registerErrorMatcher appends a mapping to the registry.
+func registerErrorMatcher(match func(error) bool, statusCode int) {
+	errorRegistry.Lock()
+	defer errorRegistry.Unlock()
+	errorRegistry.mappings = append(errorRegistry.mappings, errorMapping{match: match, status: statusCode})
+}
+
+// lookupErrorStatus returns the status registered for err, if any.
+func lookupErrorStatus(err error) (int, bool) {
+	errorRegistry.RLock()
+	defer errorRegistry.RUnlock()
+	for i := len(errorRegistry.mappings) - 1; i >= 0; i-- {
+		if m := errorRegistry.mappings[i]; m.match(err) {
+			return m.status, true
+		}
+	}
+	return 0, false
+}
+
+// SendErr translates err into a structured JSON error response.
+//
+// The error is matched against the mappings registered with
+// RegisterErrorMapping and RegisterErrorType. Mapped errors are sent with
+// their status code and err.Error() as the message. Unknown errors are
+// logged using the logger configured with SetErrorLogger and answered with a generic 500, so
+// internal details are never leaked to the client.
+//
+// The sentinel errors and *FieldError produced by this package are
+// pre-registered as 400 Bad Request, except ErrBodyTooLarge, which maps
+// to 413 Request Entity Too Large.
+func SendErr(w http.ResponseWriter, err error) {
+	if statusCode, ok := lookupErrorStatus(err); ok {
+		SendError(w, statusCode, err.Error())
+		return
+	}
+
+	getErrorLogger().Printf("unmapped handler error: %v", err)
+	SendError(w, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+}
+
+// CodedError is implemented by errors that carry a machine-readable code.
+// SendErrors includes the code in the error's entry.
+type CodedError interface {
+	error
+	Code() string
+}
+
+// FieldedError is implemented by errors tied to a specific request field.
+// SendErrors includes the field name in the error's entry.
+type FieldedError interface {
+	error
+	Field() string
+}
+
+// errorEntry is the rich form of an entry emitted by SendErrors.
+type errorEntry struct {
+	Message string `json:"message"`
+	Code    string `json:"code,omitempty"`
+	Field   string `json:"field,omitempty"`
+}
+
+// SendErrors reports several errors in a single JSON response.
+//
+// Nil errors are skipped. Plain errors are emitted as their message string;
+// errors implementing CodedError or FieldedError (or wrapping a *FieldError)
+// are emitted as objects carrying the message, code, and field:
+//
+//	{"errors":["quota exceeded",{"message":"...","field":"email"}]}
+//
+// Like SendError, it does not return an error; write failures are logged
+// using the logger configured with SetErrorLogger.
+func SendErrors(w http.ResponseWriter, statusCode int, errs []error) {
+	type errorsResponse struct {
+		Errors []any `json:"errors"`
+	}
+
+	entries := make([]any, 0, len(errs))
+	for _, err := range errs {
+		if err == nil {
+			continue
+		}
+		entries = append(entries, toErrorEntry(err))
+	}
+
+	if err := SendJSON(w, statusCode, errorsResponse{Errors: entries}); err != nil {
+		getErrorLogger().Printf("failed to send SendErrors response: %v", err)
+	}
+}
+
+// toErrorEntry returns err's message, or an errorEntry if err carries a code or field.
+func toErrorEntry(err error) any {
+	entry := errorEntry{Message: err.Error()}
+
+	var coded CodedError
+	if errors.As(err, &coded) {
+		entry.Code = coded.Code()
+	}
+	var fielded FieldedError
+	var fieldErr *FieldError
+	if errors.As(err, &fielded) {
+		entry.Field = fielded.Field()
+	} else if errors.As(err, &fieldErr) {
+		entry.Field = fieldErr.Field
+	}
+
+	if entry.Code == "" && entry.Field == "" {
+		return entry.Message
+	}
+	return entry
+}
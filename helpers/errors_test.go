@@ -0,0 +1,80 @@
+package helpers
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type conflictError struct{ resource string }
+
+func (e *conflictError) Error() string { return e.resource + " already exists" }
+
+func TestSendErr(t *testing.T) {
+	errNotFound := errors.New("user not found")
+	RegisterErrorMapping(errNotFound, http.StatusNotFound)
+	RegisterErrorType[*conflictError](http.StatusConflict)
+
+	rr := httptest.NewRecorder()
+	SendErr(rr, fmt.Errorf("lookup: %w", errNotFound))
+	assert.Equal(t, http.StatusNotFound, rr.Code, "Wrapped sentinel errors should be matched with errors.Is")
+	assert.JSONEq(t, `{"error":"lookup: user not found"}`, rr.Body.String())
+
+	rr = httptest.NewRecorder()
+	SendErr(rr, fmt.Errorf("create: %w", &conflictError{resource: "user"}))
+	assert.Equal(t, http.StatusConflict, rr.Code, "Typed errors should be matched with errors.As")
+
+	rr = httptest.NewRecorder()
+	SendErr(rr, ErrEmptyBody)
+	assert.Equal(t, http.StatusBadRequest, rr.Code, "Package sentinels should be pre-registered")
+}
+
+func TestSendErr_Unknown(t *testing.T) {
+	SetErrorLogger(log.New(io.Discard, "", 0))
+	defer SetErrorLogger(nil)
+
+	rr := httptest.NewRecorder()
+	SendErr(rr, errors.New("db password is hunter2"))
+
+	assert.Equal(t, http.StatusInternalServerError, rr.Code, "Unknown errors should default to 500")
+	assert.JSONEq(t, `{"error":"Internal Server Error"}`, rr.Body.String(), "Unknown error details must not leak")
+}
+
+type codedFieldError struct{}
+
+func (codedFieldError) Error() string { return "email is taken" }
+func (codedFieldError) Code() string  { return "taken" }
+func (codedFieldError) Field() string { return "email" }
+
+func TestSendErrors(t *testing.T) {
+	rr := httptest.NewRecorder()
+
+	SendErrors(rr, http.StatusBadRequest, []error{
+		errors.New("quota exceeded"),
+		nil,
+		codedFieldError{},
+		&FieldError{Field: "page", Expected: "int", Value: "x"},
+	})
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	expectedJSON := `{"errors":[
+		"quota exceeded",
+		{"message":"email is taken","code":"taken","field":"email"},
+		{"message":"helpers: field \"page\": expected int, got \"x\"","field":"page"}
+	]}`
+	assert.JSONEq(t, expectedJSON, rr.Body.String(), "Errors body does not match expected value")
+}
+
+func TestSendErrors_Empty(t *testing.T) {
+	rr := httptest.NewRecorder()
+
+	SendErrors(rr, http.StatusBadRequest, []error{nil})
+
+	assert.JSONEq(t, `{"errors":[]}`, rr.Body.String(), "Nil errors should be filtered out")
+}
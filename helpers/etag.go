@@ -0,0 +1,40 @@
+package helpers
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"strings"
+)
+
+// computeETag returns a strong, quoted ETag derived from the response body.
+// The first 16 bytes of the SHA-256 digest are plenty to identify a representation.
+func computeETag(body []byte) string {
+	sum := sha256.Sum256(body)
+	var out [2 + 2*16]byte
+	out[0] = '"'
+	hex.Encode(out[1:], sum[:16])
+	out[len(out)-1] = '"'
+	return string(out[:])
+}
+
+// etagMatches reports whether a GET or HEAD request's If-None-Match header
+// matches etag. Comparison is weak, as required by RFC 9110 for If-None-Match.
+func etagMatches(r *http.Request, etag string) bool {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		return false
+	}
+
+	header := r.Header.Get("If-None-Match")
+	if header == "" {
+		return false
+	}
+
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == etag {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,68 @@
+package helpers
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"mime"
+	"net/http"
+	"os"
+	"path/filepath"
+)
+
+// sniffLen is the number of bytes inspected by http.DetectContentType.
+const sniffLen = 512
+
+// SendFile serves the file at path with content-type detection and range support.
+//
+// The Content-Type is resolved from the file extension first and falls back to
+// sniffing the leading bytes of the file. The response is delegated to
+// http.ServeContent, which sets Content-Length, honors Range and conditional
+// request headers, and handles HEAD requests.
+//
+// If the file is missing (or is a directory), a 404 JSON error is sent; any
+// other failure to open or read it results in a 500. In both cases the
+// underlying error is returned to the caller.
+func SendFile(w http.ResponseWriter, r *http.Request, path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return sendFileError(w, err)
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return sendFileError(w, err)
+	}
+	if info.IsDir() {
+		return sendFileError(w, fmt.Errorf("helpers: %s is a directory: %w", path, fs.ErrNotExist))
+	}
+
+	contentType := mime.TypeByExtension(filepath.Ext(path))
+	if contentType == "" {
+		var head [sniffLen]byte
+		n, err := io.ReadFull(f, head[:])
+		if err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, io.ErrUnexpectedEOF) {
+			return sendFileError(w, err)
+		}
+		contentType = http.DetectContentType(head[:n])
+		if _, err := f.Seek(0, io.SeekStart); err != nil {
+			return sendFileError(w, err)
+		}
+	}
+	w.Header().Set("Content-Type", contentType)
+
+	http.ServeContent(w, r, info.Name(), info.ModTime(), f)
+	return nil
+}
+
+// sendFileError maps a file access error to a JSON error response and returns it.
+func sendFileError(w http.ResponseWriter, err error) error {
+	if errors.Is(err, fs.ErrNotExist) {
+		SendError(w, http.StatusNotFound, http.StatusText(http.StatusNotFound))
+	} else {
+		SendError(w, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+	}
+	return err
+}
@@ -0,0 +1,75 @@
+package helpers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSendFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "report.json")
+	assert.NoError(t, os.WriteFile(path, []byte(`{"ok":true}`), 0o644))
+
+	req := httptest.NewRequest("GET", "/report", nil)
+	rr := httptest.NewRecorder()
+
+	err := SendFile(rr, req, path)
+
+	assert.NoError(t, err, "SendFile should not fail")
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, "application/json", rr.Header().Get("Content-Type"), "Content-Type should come from the extension")
+	assert.Equal(t, "11", rr.Header().Get("Content-Length"))
+	assert.Equal(t, `{"ok":true}`, rr.Body.String())
+}
+
+func TestSendFile_Sniffing(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "noext")
+	assert.NoError(t, os.WriteFile(path, []byte("<html><body>hi</body></html>"), 0o644))
+
+	req := httptest.NewRequest("GET", "/", nil)
+	rr := httptest.NewRecorder()
+
+	assert.NoError(t, SendFile(rr, req, path))
+	assert.Equal(t, "text/html; charset=utf-8", rr.Header().Get("Content-Type"), "Content-Type should be sniffed when the extension is unknown")
+}
+
+func TestSendFile_Range(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "data.txt")
+	assert.NoError(t, os.WriteFile(path, []byte("0123456789"), 0o644))
+
+	req := httptest.NewRequest("GET", "/", nil)
+	req.Header.Set("Range", "bytes=2-4")
+	rr := httptest.NewRecorder()
+
+	assert.NoError(t, SendFile(rr, req, path))
+	assert.Equal(t, http.StatusPartialContent, rr.Code)
+	assert.Equal(t, "234", rr.Body.String())
+}
+
+func TestSendFile_NotFound(t *testing.T) {
+	req := httptest.NewRequest("GET", "/", nil)
+	rr := httptest.NewRecorder()
+
+	err := SendFile(rr, req, filepath.Join(t.TempDir(), "missing.txt"))
+
+	assert.Error(t, err, "Missing files should return an error")
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+	assert.JSONEq(t, `{"error":"Not Found"}`, rr.Body.String())
+}
+
+func TestSendFile_Directory(t *testing.T) {
+	req := httptest.NewRequest("GET", "/", nil)
+	rr := httptest.NewRecorder()
+
+	err := SendFile(rr, req, t.TempDir())
+
+	assert.Error(t, err, "Directories should not be served")
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+}
@@ -0,0 +1,134 @@
+package helpers
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// messageCatalogs holds the localized messages used by SendErrorLocalized.
+var messageCatalogs = struct {
+	sync.RWMutex
+	defaultLocale string
+	catalogs      map[string]map[string]string
+}{
+	defaultLocale: "en",
+	catalogs:      make(map[string]map[string]string),
+}
+
+// RegisterMessages adds catalog to the messages available for locale.
+//
+// Catalog values are fmt format strings, filled with the args passed to
+// SendErrorLocalized. Locales are matched case-insensitively; registering
+// the same locale again merges the catalogs, with new keys overriding old ones.
+func RegisterMessages(locale string, catalog map[string]string) {
+	locale = strings.ToLower(locale)
+
+	messageCatalogs.Lock()
+	defer messageCatalogs.Unlock()
+
+	existing := messageCatalogs.catalogs[locale]
+	if existing == nil {
+		existing = make(map[string]string, len(catalog))
+		messageCatalogs.catalogs[locale] = existing
+	}
+	for key, msg := range catalog {
+		existing[key] = msg
+	}
+}
+
+// SetDefaultLocale sets the locale used when none of the request's
+// Accept-Language preferences has a message for the key. The default is "en".
+func SetDefaultLocale(locale string) {
+	messageCatalogs.Lock()
+	defer messageCatalogs.Unlock()
+	messageCatalogs.defaultLocale = strings.ToLower(locale)
+}
+
+// SendErrorLocalized sends a JSON error response whose message is resolved
+// from the registered catalogs according to the request's Accept-Language header.
+//
+// Preferences are tried in order of quality; for each, the exact locale
+// (e.g. "pt-br") is tried before its base language ("pt"). If no preferred
+// locale has the key, the default locale is used, and if that has none
+// either, key itself is sent unchanged, ignoring args. The chosen locale is
+// reported in the Content-Language header.
+//
+// Example:
+//
+//	helpers.RegisterMessages("en", map[string]string{"user.not_found": "User %s not found"})
+//	helpers.RegisterMessages("id", map[string]string{"user.not_found": "Pengguna %s tidak ditemukan"})
+//	helpers.SendErrorLocalized(w, r, http.StatusNotFound, "user.not_found", id)
+func SendErrorLocalized(w http.ResponseWriter, r *http.Request, statusCode int, key string, args ...any) {
+	locale, format, ok := resolveMessage(r.Header.Get("Accept-Language"), key)
+
+	// An unresolved key is sent as is; it is not a format string.
+	message := format
+	if ok {
+		message = fmt.Sprintf(format, args...)
+		w.Header().Set("Content-Language", locale)
+	}
+	SendError(w, statusCode, message)
+}
+
+// resolveMessage returns the best locale and message format for key.
+// If no catalog has the key, it returns an empty locale, the key itself, and false.
+func resolveMessage(acceptLanguage, key string) (locale, format string, ok bool) {
+	messageCatalogs.RLock()
+	defer messageCatalogs.RUnlock()
+
+	candidates := append(parseAcceptLanguage(acceptLanguage), messageCatalogs.defaultLocale)
+	for _, tag := range candidates {
+		for _, loc := range []string{tag, baseLanguage(tag)} {
+			if msg, ok := messageCatalogs.catalogs[loc][key]; ok {
+				return loc, msg, true
+			}
+		}
+	}
+	return "", key, false
+}
+
+// parseAcceptLanguage returns the language tags of an Accept-Language header,
+// lowercased and ordered by descending quality. Tags with q=0 are dropped.
+func parseAcceptLanguage(header string) []string {
+	type weighted struct {
+		tag string
+		q   float64
+	}
+
+	var prefs []weighted
+	for _, part := range strings.Split(header, ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		if tag == "" || tag == "*" {
+			continue
+		}
+
+		q := 1.0
+		if v, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			if parsed, err := strconv.ParseFloat(v, 64); err == nil {
+				q = parsed
+			}
+		}
+		if q > 0 {
+			prefs = append(prefs, weighted{tag: tag, q: q})
+		}
+	}
+
+	sort.SliceStable(prefs, func(i, j int) bool { return prefs[i].q > prefs[j].q })
+
+	tags := make([]string, len(prefs))
+	for i, p := range prefs {
+		tags[i] = p.tag
+	}
+	return tags
+}
+
+// baseLanguage returns the primary subtag of a language tag, e.g. "pt" for "pt-br".
+func baseLanguage(tag string) string {
+	base, _, _ := strings.Cut(tag, "-")
+	return base
+}
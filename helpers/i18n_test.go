@@ -0,0 +1,68 @@
+package helpers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSendErrorLocalized(t *testing.T) {
+	RegisterMessages("en", map[string]string{"user.not_found": "User %s not found"})
+	RegisterMessages("id", map[string]string{"user.not_found": "Pengguna %s tidak ditemukan"})
+	RegisterMessages("pt-BR", map[string]string{"user.not_found": "Usuário %s não encontrado"})
+
+	cases := []struct {
+		acceptLanguage string
+		locale         string
+		message        string
+	}{
+		{"id-ID,en;q=0.8", "id", "Pengguna 42 tidak ditemukan"},
+		{"fr, pt-BR;q=0.9, id;q=0.5", "pt-br", "Usuário 42 não encontrado"},
+		{"en;q=0.2, id;q=0.9", "id", "Pengguna 42 tidak ditemukan"},
+		{"fr", "en", "User 42 not found"},
+		{"", "en", "User 42 not found"},
+	}
+
+	for _, tc := range cases {
+		req := httptest.NewRequest("GET", "/", nil)
+		req.Header.Set("Accept-Language", tc.acceptLanguage)
+		rr := httptest.NewRecorder()
+
+		SendErrorLocalized(rr, req, http.StatusNotFound, "user.not_found", "42")
+
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+		assert.Equal(t, tc.locale, rr.Header().Get("Content-Language"), "Unexpected locale for %q", tc.acceptLanguage)
+		assert.JSONEq(t, `{"error":"`+tc.message+`"}`, rr.Body.String(), "Unexpected message for %q", tc.acceptLanguage)
+	}
+}
+
+func TestSendErrorLocalized_UnknownKey(t *testing.T) {
+	req := httptest.NewRequest("GET", "/", nil)
+	rr := httptest.NewRecorder()
+
+	SendErrorLocalized(rr, req, http.StatusBadRequest, "unknown.key")
+
+	assert.Empty(t, rr.Header().Get("Content-Language"))
+	assert.JSONEq(t, `{"error":"unknown.key"}`, rr.Body.String(), "Unknown keys should fall back to the key itself")
+}
+
+func TestSendErrorLocalized_UnknownKeyWithArgs(t *testing.T) {
+	req := httptest.NewRequest("GET", "/", nil)
+	rr := httptest.NewRecorder()
+
+	SendErrorLocalized(rr, req, http.StatusNotFound, "user.missing_%d", 42)
+
+	assert.JSONEq(t, `{"error":"user.missing_%d"}`, rr.Body.String(), "Unknown keys should not be used as format strings")
+}
+
+func TestSendErrorLocalized_EscapedPercentWithoutArgs(t *testing.T) {
+	RegisterMessages("en", map[string]string{"quota.full": "Quota at 100%%"})
+	req := httptest.NewRequest("GET", "/", nil)
+	rr := httptest.NewRecorder()
+
+	SendErrorLocalized(rr, req, http.StatusTooManyRequests, "quota.full")
+
+	assert.JSONEq(t, `{"error":"Quota at 100%"}`, rr.Body.String(), "Resolved messages should always be formatted")
+}
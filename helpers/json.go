@@ -2,41 +2,280 @@ package helpers
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
-	"log"
+	"errors"
+	"io"
 	"net/http"
-	"os"
+	"strconv"
+	"time"
 
 	"github.com/iameggi/cassie/bucket"
 )
 
-// defaultErrorLogger is used only if SendError itself fails.
-// It writes to stderr with a consistent prefix.
-var defaultErrorLogger = log.New(os.Stderr, "CASSIE HELPER ERROR: ", log.LstdFlags)
+// JSONMarshaler is implemented by types that can write their own JSON
+// representation directly to a writer.
+//
+// When a value passed to the SendJSON family implements JSONMarshaler, its
+// MarshalJSONTo method streams straight into the pooled buffer instead of going
+// through encoding/json, avoiding an intermediate []byte. The output is not
+// validated, so implementations must produce well-formed JSON.
+type JSONMarshaler interface {
+	MarshalJSONTo(w io.Writer) error
+}
 
 // SendJSON writes a high-performance JSON response using Cassie's pooled buffers.
 //
 // This helper automatically sets the Content-Type header and encodes the given data
-// into a pooled *bytes.Buffer to minimize memory allocations and GC overhead.
+// into a pooled *bytes.Buffer with a pooled encoder to minimize memory allocations
+// and GC overhead. Because the body is fully buffered, Content-Length is set too.
 //
-// Returns an error if JSON encoding or writing to the client fails.
+// Returns an error if JSON encoding or writing to the client fails. An encoding
+// failure leaves w untouched (no headers, status, or body are written), so the
+// caller remains free to send an error response of its choosing.
 func SendJSON(w http.ResponseWriter, statusCode int, data interface{}) error {
-	return bucket.WithByteBufferErr(func(buf *bytes.Buffer) error {
-		// Encode JSON directly into the pooled buffer.
-		if err := json.NewEncoder(buf).Encode(data); err != nil {
-			http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
-			return err
+	_, err := sendJSON(w, statusCode, data, jsonConfig{})
+	return err
+}
+
+// SendJSONN behaves like SendJSON, but also reports the number of body bytes
+// written to the client. Useful for metrics and access logging.
+//
+// On an encoding failure nothing is written and n is 0.
+func SendJSONN(w http.ResponseWriter, statusCode int, data interface{}) (n int, err error) {
+	return sendJSON(w, statusCode, data, jsonConfig{})
+}
+
+// SendJSONWithHeaders behaves like SendJSON, but also applies the given headers
+// to the response (e.g. Cache-Control, Location, or ETag).
+//
+// The extra headers are set after Content-Type and before the status code is
+// written, so they take effect and may override the default content type.
+func SendJSONWithHeaders(w http.ResponseWriter, statusCode int, data interface{}, headers http.Header) error {
+	_, err := sendJSON(w, statusCode, data, jsonConfig{headers: headers})
+	return err
+}
+
+// SendJSONRequest behaves like SendJSON, but is aware of the request being answered.
+//
+// For HEAD requests the body is encoded to compute an accurate Content-Length,
+// and all headers are written, but the body itself is not sent.
+func SendJSONRequest(w http.ResponseWriter, r *http.Request, statusCode int, data interface{}) error {
+	_, err := sendJSON(w, statusCode, data, jsonConfig{request: r})
+	return err
+}
+
+// SendJSONWithETag behaves like SendJSON, but also computes a strong ETag from
+// the encoded body and honors the request's If-None-Match header.
+//
+// When statusCode is 200 and r is a GET or HEAD request whose If-None-Match
+// matches the computed ETag, a 304 Not Modified is sent without a body.
+// The ETag header is set in both cases. HEAD requests are handled as in
+// SendJSONRequest. A nil r is treated as no request: the ETag is set, but
+// the response is always sent in full.
+func SendJSONWithETag(w http.ResponseWriter, r *http.Request, statusCode int, data interface{}) error {
+	_, err := sendJSON(w, statusCode, data, jsonConfig{request: r, etag: true})
+	return err
+}
+
+// SendJSONCtx behaves like SendJSON, but first checks ctx and returns ctx.Err()
+// without encoding anything if it is already done.
+//
+// Pass r.Context() to skip the encoding work for clients that have already
+// disconnected. The context is checked again after encoding, so a large
+// payload is not written to a client that went away in the meantime.
+func SendJSONCtx(ctx context.Context, w http.ResponseWriter, statusCode int, data interface{}) error {
+	_, err := sendJSON(w, statusCode, data, jsonConfig{ctx: ctx})
+	return err
+}
+
+// SendJSONNoEscape behaves like SendJSON, but disables HTML escaping so that
+// characters such as <, >, and & are written verbatim instead of as \u003c etc.
+//
+// Use it only for responses that are never embedded in HTML; the escaping
+// performed by SendJSON is the safer default.
+func SendJSONNoEscape(w http.ResponseWriter, statusCode int, data interface{}) error {
+	_, err := sendJSON(w, statusCode, data, jsonConfig{noEscapeHTML: true})
+	return err
+}
+
+// SendJSONCached behaves like SendJSON, but marks the response as publicly
+// cacheable for maxAge.
+//
+// It sets "Cache-Control: public, max-age=N" (with ", immutable" appended when
+// immutable is true, for content that never changes at this URL) and a
+// matching Expires header for HTTP/1.0 caches.
+func SendJSONCached(w http.ResponseWriter, statusCode int, data interface{}, maxAge time.Duration, immutable bool) error {
+	seconds := int64(maxAge / time.Second)
+	if seconds < 0 {
+		seconds = 0
+	}
+
+	cacheControl := "public, max-age=" + strconv.FormatInt(seconds, 10)
+	if immutable {
+		cacheControl += ", immutable"
+	}
+
+	headers := http.Header{}
+	headers.Set("Cache-Control", cacheControl)
+	headers.Set("Expires", time.Now().Add(time.Duration(seconds)*time.Second).UTC().Format(http.TimeFormat))
+
+	_, err := sendJSON(w, statusCode, data, jsonConfig{headers: headers})
+	return err
+}
+
+// jsonConfig carries the optional behaviors of the SendJSON family.
+// The zero value produces a plain SendJSON response.
+type jsonConfig struct {
+	ctx           context.Context // If set, encoding is skipped once the context is done.
+	headers       http.Header     // Extra headers applied before WriteHeader.
+	request       *http.Request   // If set, HEAD requests get headers only.
+	etag          bool            // Generates an ETag and honors If-None-Match (requires request).
+	noEscapeHTML  bool            // Disables the encoder's HTML escaping.
+	stringNumbers bool            // Quotes integers outside the JavaScript safe range.
+	compress      bool            // Gzips the body when the client accepts it (requires request).
+	indentPrefix  string          // Passed to the encoder's SetIndent, with indent.
+	indent        string          // Indentation per level; empty disables indenting.
+}
+
+// sendJSON is the shared implementation behind the SendJSON family.
+// It returns the number of body bytes written to w.
+func sendJSON(w http.ResponseWriter, statusCode int, data interface{}, cfg jsonConfig) (n int, err error) {
+	if cfg.ctx != nil && cfg.ctx.Err() != nil {
+		return 0, cfg.ctx.Err()
+	}
+
+	// The body is encoded into a pooled buffer first. Nothing touches w until
+	// it is fully prepared, so on failure the caller can still send a response
+	// of its own choosing.
+	err = withEncodedJSON(data, cfg, func(buf *bytes.Buffer) error {
+		// Don't bother writing if the client went away while we were encoding.
+		if cfg.ctx != nil && cfg.ctx.Err() != nil {
+			return cfg.ctx.Err()
+		}
+
+		var etag string
+		notModified := false
+		if cfg.etag {
+			etag = computeETag(buf.Bytes())
+			notModified = statusCode == http.StatusOK && cfg.request != nil && etagMatches(cfg.request, etag)
+		}
+
+		body := buf
+		compressed := false
+		if cfg.compress && !notModified && buf.Len() >= compressThreshold && acceptsGzip(cfg.request) {
+			zbuf := bucket.ByteBucket.Get()
+			defer bucket.ByteBucket.Put(zbuf)
+			if err := gzipInto(zbuf, buf.Bytes()); err != nil {
+				return err
+			}
+			body = zbuf
+			compressed = true
 		}
 
 		// Write headers and response body.
-		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		h := w.Header()
+		h.Set("Content-Type", "application/json; charset=utf-8")
+		for key, values := range cfg.headers {
+			h[http.CanonicalHeaderKey(key)] = values
+		}
+
+		if cfg.etag {
+			h.Set("ETag", etag)
+			if notModified {
+				h.Del("Content-Type")
+				w.WriteHeader(http.StatusNotModified)
+				return nil
+			}
+		}
+
+		if cfg.compress {
+			h.Add("Vary", "Accept-Encoding")
+		}
+		if compressed {
+			h.Set("Content-Encoding", "gzip")
+		}
+
+		// The whole body, compressed or not, is buffered, so its length is
+		// known up front and the response need not be chunked.
+		h.Set("Content-Length", strconv.Itoa(body.Len()))
+
+		// HEAD responses carry the headers of the equivalent GET, but no body.
+		if cfg.request != nil && cfg.request.Method == http.MethodHead {
+			w.WriteHeader(statusCode)
+			return nil
+		}
+
 		w.WriteHeader(statusCode)
 
-		if _, err := w.Write(buf.Bytes()); err != nil {
-			// Handle client write errors (e.g., broken pipe).
+		// Handle client write errors (e.g., broken pipe).
+		n, err = w.Write(body.Bytes())
+		return err
+	})
+	return n, err
+}
+
+// EncodeJSON encodes data as JSON and writes it to w, returning the number of
+// bytes written.
+//
+// It is the encoding core of SendJSON, usable outside HTTP handlers, e.g.
+// for files or websocket frames. The value is encoded into a pooled buffer
+// with a pooled encoder (or its own MarshalJSONTo method, see JSONMarshaler)
+// and then handed to w in a single Write, so w never sees a partial value:
+// on an encoding failure nothing is written and n is 0. As with
+// json.Encoder, the output ends with a newline.
+func EncodeJSON(w io.Writer, data any) (n int, err error) {
+	err = withEncodedJSON(data, jsonConfig{}, func(buf *bytes.Buffer) error {
+		n, err = w.Write(buf.Bytes())
+		return err
+	})
+	return n, err
+}
+
+// withEncodedJSON encodes data into a pooled buffer according to cfg and
+// calls f with the result. The buffer is returned to the pool when f returns.
+func withEncodedJSON(data any, cfg jsonConfig, f func(buf *bytes.Buffer) error) error {
+	return bucket.WithByteBufferErr(func(buf *bytes.Buffer) error {
+		if err := encodeJSON(buf, data, cfg); err != nil {
+			return err
+		}
+
+		if cfg.stringNumbers {
+			// encodeJSON does not validate JSONMarshaler output; check it
+			// before rewriting it.
+			if _, ok := data.(JSONMarshaler); ok && !json.Valid(buf.Bytes()) {
+				return errors.New("helpers: JSONMarshaler produced invalid JSON")
+			}
+			quoted := bucket.ByteBucket.Get()
+			defer bucket.ByteBucket.Put(quoted)
+			quoteUnsafeIntegers(quoted, buf.Bytes())
+			buf = quoted
+		}
+
+		return f(buf)
+	})
+}
+
+// encodeJSON writes data into buf, preferring the value's own JSONMarshaler
+// implementation and falling back to a pooled encoder. Either way, the output
+// is terminated by a newline, matching json.Encoder.
+func encodeJSON(buf *bytes.Buffer, data interface{}, cfg jsonConfig) error {
+	if m, ok := data.(JSONMarshaler); ok {
+		if err := m.MarshalJSONTo(buf); err != nil {
 			return err
 		}
+		buf.WriteByte('\n')
 		return nil
+	}
+
+	return bucket.WithJSONEncoder(buf, func(enc *bucket.JSONEncoder) error {
+		if cfg.noEscapeHTML {
+			enc.SetEscapeHTML(false)
+		}
+		if cfg.indent != "" || cfg.indentPrefix != "" {
+			enc.SetIndent(cfg.indentPrefix, cfg.indent)
+		}
+		return enc.Encode(data)
 	})
 }
 
@@ -44,13 +283,49 @@ func SendJSON(w http.ResponseWriter, statusCode int, data interface{}) error {
 //
 // It wraps SendJSON to ensure consistent error formatting across your application.
 // SendError does not return an error itself — if the response write fails,
-// the failure is logged using defaultErrorLogger.
+// the failure is logged using the logger configured with SetErrorLogger.
 func SendError(w http.ResponseWriter, statusCode int, message string) {
 	type errorResponse struct {
 		Error string `json:"error"`
 	}
 
 	if err := SendJSON(w, statusCode, errorResponse{Error: message}); err != nil {
-		defaultErrorLogger.Printf("failed to send SendError response: %v", err)
+		getErrorLogger().Printf("failed to send SendError response: %v", err)
 	}
 }
+
+// SendValidationError sends a 422 Unprocessable Entity response describing
+// which request fields are invalid.
+//
+// The response body has the form:
+//
+//	{"error":"validation failed","fields":{"email":"required"}}
+//
+// Like SendError, it does not return an error; write failures are logged
+// using the logger configured with SetErrorLogger.
+func SendValidationError(w http.ResponseWriter, fields map[string]string) {
+	type validationResponse struct {
+		Error  string            `json:"error"`
+		Fields map[string]string `json:"fields"`
+	}
+
+	resp := validationResponse{Error: "validation failed", Fields: fields}
+	if err := SendJSON(w, http.StatusUnprocessableEntity, resp); err != nil {
+		getErrorLogger().Printf("failed to send SendValidationError response: %v", err)
+	}
+}
+
+// SendSuccess sends data wrapped in a uniform success envelope:
+//
+//	{"success":true,"data":...}
+//
+// It complements SendError for APIs that standardize on enveloped responses.
+// Use SendJSON for the raw, unwrapped form.
+func SendSuccess(w http.ResponseWriter, statusCode int, data any) error {
+	type successResponse struct {
+		Success bool `json:"success"`
+		Data    any  `json:"data"`
+	}
+
+	return SendJSON(w, statusCode, successResponse{Success: true, Data: data})
+}
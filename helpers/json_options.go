@@ -0,0 +1,64 @@
+package helpers
+
+import "net/http"
+
+// JSONOption customizes a response sent with SendJSONWith.
+type JSONOption func(*jsonConfig)
+
+// WithIndent indents the encoded JSON like json.Encoder.SetIndent, e.g. for
+// human-readable debug endpoints. It does not apply to values implementing
+// JSONMarshaler, which control their own formatting.
+func WithIndent(prefix, indent string) JSONOption {
+	return func(cfg *jsonConfig) {
+		cfg.indentPrefix = prefix
+		cfg.indent = indent
+	}
+}
+
+// WithEscapeHTML controls whether characters such as <, >, and & are escaped
+// as \u003c etc. Escaping is enabled by default; see SendJSONNoEscape.
+func WithEscapeHTML(enabled bool) JSONOption {
+	return func(cfg *jsonConfig) {
+		cfg.noEscapeHTML = !enabled
+	}
+}
+
+// WithHeaders applies the given headers to the response, as
+// SendJSONWithHeaders does. Repeated use replaces earlier headers.
+func WithHeaders(headers http.Header) JSONOption {
+	return func(cfg *jsonConfig) {
+		cfg.headers = headers
+	}
+}
+
+// WithStringNumbers quotes integers outside the JavaScript safe range, as
+// SendJSONStringNumbers does.
+func WithStringNumbers() JSONOption {
+	return func(cfg *jsonConfig) {
+		cfg.stringNumbers = true
+	}
+}
+
+// SendJSONWith behaves like SendJSON, with its behavior adjusted by opts.
+//
+// It is the composable alternative to the specialized SendJSON variants:
+// options can be freely combined, and every option works on the same pooled
+// buffer and encoder path. Without options it is equivalent to SendJSON,
+// which remains the fastest way to send a plain response.
+//
+// Example:
+//
+//	helpers.SendJSONWith(w, http.StatusOK, report,
+//		helpers.WithIndent("", "  "),
+//		helpers.WithEscapeHTML(false),
+//		helpers.WithHeaders(http.Header{"Cache-Control": {"no-store"}}),
+//	)
+func SendJSONWith(w http.ResponseWriter, statusCode int, data interface{}, opts ...JSONOption) error {
+	var cfg jsonConfig
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	_, err := sendJSON(w, statusCode, data, cfg)
+	return err
+}
@@ -0,0 +1,46 @@
+package helpers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSendJSONWith_NoOptions(t *testing.T) {
+	data := map[string]string{"tag": "<b>"}
+
+	rr := httptest.NewRecorder()
+	err := SendJSONWith(rr, http.StatusCreated, data)
+
+	assert.NoError(t, err, "SendJSONWith should not fail")
+	assert.Equal(t, http.StatusCreated, rr.Code)
+	assert.Equal(t, "application/json; charset=utf-8", rr.Header().Get("Content-Type"))
+	assert.Equal(t, "{\"tag\":\"\\u003cb\\u003e\"}\n", rr.Body.String(), "Defaults should match SendJSON")
+}
+
+func TestSendJSONWith_CombinedOptions(t *testing.T) {
+	data := map[string]any{"id": int64(1 << 60), "tag": "<b>"}
+
+	rr := httptest.NewRecorder()
+	err := SendJSONWith(rr, http.StatusOK, data,
+		WithIndent("", "  "),
+		WithEscapeHTML(false),
+		WithStringNumbers(),
+		WithHeaders(http.Header{"Cache-Control": {"no-store"}}),
+	)
+
+	assert.NoError(t, err, "SendJSONWith should not fail")
+	assert.Equal(t, "{\n  \"id\": \"1152921504606846976\",\n  \"tag\": \"<b>\"\n}\n", rr.Body.String())
+	assert.Equal(t, "no-store", rr.Header().Get("Cache-Control"))
+}
+
+func TestSendJSONWith_SettingsDoNotLeak(t *testing.T) {
+	rr := httptest.NewRecorder()
+	assert.NoError(t, SendJSONWith(rr, http.StatusOK, []int{1}, WithIndent(">", "\t")))
+
+	rr = httptest.NewRecorder()
+	assert.NoError(t, SendJSON(rr, http.StatusOK, []int{1}))
+	assert.Equal(t, "[1]\n", rr.Body.String(), "Pooled encoder settings must be reset between uses")
+}
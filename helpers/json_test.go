@@ -1,10 +1,16 @@
 package helpers
 
 import (
+	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
+	"io"
 	"net/http"
 	"net/http/httptest"
+	"strconv"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -26,6 +32,7 @@ func TestSendJSON(t *testing.T) {
 
 	expectedHeader := "application/json; charset=utf-8"
 	assert.Equal(t, expectedHeader, rr.Header().Get("Content-Type"), "Incorrect Content-Type header")
+	assert.Equal(t, strconv.Itoa(rr.Body.Len()), rr.Header().Get("Content-Length"), "Content-Length should match the body")
 
 	var responseData testData
 	err = json.Unmarshal(rr.Body.Bytes(), &responseData)
@@ -47,3 +54,287 @@ func TestSendError(t *testing.T) {
 	expectedJSON := `{"error":"User not found"}`
 	assert.JSONEq(t, expectedJSON, rr.Body.String(), "Error JSON body does not match expected value")
 }
+
+func TestSendJSONWithHeaders(t *testing.T) {
+	rr := httptest.NewRecorder()
+
+	headers := http.Header{}
+	headers.Set("Cache-Control", "max-age=60")
+	headers.Set("Location", "/users/1")
+
+	err := SendJSONWithHeaders(rr, http.StatusCreated, map[string]int{"id": 1}, headers)
+
+	assert.NoError(t, err, "SendJSONWithHeaders should not fail")
+	assert.Equal(t, http.StatusCreated, rr.Code)
+	assert.Equal(t, "application/json; charset=utf-8", rr.Header().Get("Content-Type"), "Incorrect Content-Type header")
+	assert.Equal(t, "max-age=60", rr.Header().Get("Cache-Control"), "Extra headers should be applied")
+	assert.Equal(t, "/users/1", rr.Header().Get("Location"), "Extra headers should be applied")
+	assert.JSONEq(t, `{"id":1}`, rr.Body.String())
+}
+
+func TestSendValidationError(t *testing.T) {
+	rr := httptest.NewRecorder()
+
+	SendValidationError(rr, map[string]string{"email": "required", "age": "must be positive"})
+
+	assert.Equal(t, http.StatusUnprocessableEntity, rr.Code, "Status code should be 422 Unprocessable Entity")
+	assert.Equal(t, "application/json; charset=utf-8", rr.Header().Get("Content-Type"), "Incorrect Content-Type header")
+
+	expectedJSON := `{"error":"validation failed","fields":{"email":"required","age":"must be positive"}}`
+	assert.JSONEq(t, expectedJSON, rr.Body.String(), "Validation error body does not match expected value")
+}
+
+func TestSendJSONWithETag(t *testing.T) {
+	data := map[string]string{"name": "Cassie"}
+
+	req := httptest.NewRequest("GET", "/", nil)
+	rr := httptest.NewRecorder()
+
+	err := SendJSONWithETag(rr, req, http.StatusOK, data)
+
+	assert.NoError(t, err, "SendJSONWithETag should not fail")
+	assert.Equal(t, http.StatusOK, rr.Code)
+	etag := rr.Header().Get("ETag")
+	assert.NotEmpty(t, etag, "ETag header should be set")
+	assert.JSONEq(t, `{"name":"Cassie"}`, rr.Body.String())
+
+	req = httptest.NewRequest("GET", "/", nil)
+	req.Header.Set("If-None-Match", etag)
+	rr = httptest.NewRecorder()
+
+	err = SendJSONWithETag(rr, req, http.StatusOK, data)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusNotModified, rr.Code, "Matching If-None-Match should return 304")
+	assert.Equal(t, etag, rr.Header().Get("ETag"), "ETag should be stable for identical bodies")
+	assert.Empty(t, rr.Body.String(), "304 responses must not carry a body")
+}
+
+func TestSendJSONWithETag_Mismatch(t *testing.T) {
+	req := httptest.NewRequest("GET", "/", nil)
+	req.Header.Set("If-None-Match", `"stale", W/"other"`)
+	rr := httptest.NewRecorder()
+
+	err := SendJSONWithETag(rr, req, http.StatusOK, map[string]int{"v": 2})
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rr.Code, "Non-matching If-None-Match should return the full body")
+	assert.JSONEq(t, `{"v":2}`, rr.Body.String())
+}
+
+func TestSendJSONWithETag_NilRequest(t *testing.T) {
+	rr := httptest.NewRecorder()
+
+	err := SendJSONWithETag(rr, nil, http.StatusOK, map[string]int{"v": 3})
+
+	assert.NoError(t, err, "A nil request should not panic")
+	assert.Equal(t, http.StatusOK, rr.Code, "A nil request should get the full body")
+	assert.NotEmpty(t, rr.Header().Get("ETag"), "The ETag should still be set")
+	assert.JSONEq(t, `{"v":3}`, rr.Body.String())
+}
+
+func TestSendJSONCtx(t *testing.T) {
+	rr := httptest.NewRecorder()
+
+	err := SendJSONCtx(context.Background(), rr, http.StatusOK, map[string]int{"id": 1})
+
+	assert.NoError(t, err, "SendJSONCtx should not fail with a live context")
+	assert.JSONEq(t, `{"id":1}`, rr.Body.String())
+}
+
+func TestSendJSONCtx_Canceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	rr := httptest.NewRecorder()
+	err := SendJSONCtx(ctx, rr, http.StatusOK, map[string]int{"id": 1})
+
+	assert.ErrorIs(t, err, context.Canceled, "A done context should abort before encoding")
+	assert.Empty(t, rr.Body.String(), "Nothing should be written for an abandoned request")
+}
+
+func TestSendJSONN(t *testing.T) {
+	rr := httptest.NewRecorder()
+
+	n, err := SendJSONN(rr, http.StatusOK, map[string]string{"name": "Cassie"})
+
+	assert.NoError(t, err, "SendJSONN should not fail")
+	assert.Equal(t, rr.Body.Len(), n, "Reported byte count should match the body length")
+	assert.Equal(t, len("{\"name\":\"Cassie\"}\n"), n)
+}
+
+func TestSendJSONNoEscape(t *testing.T) {
+	data := map[string]string{"url": "https://example.com/?a=1&b=<2>"}
+
+	rr := httptest.NewRecorder()
+	assert.NoError(t, SendJSONNoEscape(rr, http.StatusOK, data))
+	assert.Equal(t, "{\"url\":\"https://example.com/?a=1&b=<2>\"}\n", rr.Body.String(), "HTML characters should be written verbatim")
+
+	rr = httptest.NewRecorder()
+	assert.NoError(t, SendJSON(rr, http.StatusOK, data))
+	assert.Contains(t, rr.Body.String(), `\u0026`, "SendJSON should keep escaping HTML by default")
+}
+
+func TestSendJSONCached(t *testing.T) {
+	rr := httptest.NewRecorder()
+
+	err := SendJSONCached(rr, http.StatusOK, map[string]int{"v": 1}, 10*time.Minute, false)
+
+	assert.NoError(t, err, "SendJSONCached should not fail")
+	assert.Equal(t, "public, max-age=600", rr.Header().Get("Cache-Control"))
+
+	expires, err := http.ParseTime(rr.Header().Get("Expires"))
+	assert.NoError(t, err, "Expires should be a valid HTTP date")
+	assert.WithinDuration(t, time.Now().Add(10*time.Minute), expires, 2*time.Second)
+
+	rr = httptest.NewRecorder()
+	assert.NoError(t, SendJSONCached(rr, http.StatusOK, map[string]int{"v": 1}, time.Hour, true))
+	assert.Equal(t, "public, max-age=3600, immutable", rr.Header().Get("Cache-Control"), "Immutable flag should be appended")
+}
+
+func TestSendSuccess(t *testing.T) {
+	rr := httptest.NewRecorder()
+
+	err := SendSuccess(rr, http.StatusOK, map[string]string{"name": "Cassie"})
+
+	assert.NoError(t, err, "SendSuccess should not fail")
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.JSONEq(t, `{"success":true,"data":{"name":"Cassie"}}`, rr.Body.String(), "Success envelope does not match expected value")
+}
+
+// streamingPayload implements JSONMarshaler with a hand-written encoding.
+type streamingPayload struct {
+	ids []int
+	err error
+}
+
+func (p streamingPayload) MarshalJSONTo(w io.Writer) error {
+	if p.err != nil {
+		return p.err
+	}
+	io.WriteString(w, `{"ids":[`)
+	for i, id := range p.ids {
+		if i > 0 {
+			io.WriteString(w, ",")
+		}
+		io.WriteString(w, strconv.Itoa(id))
+	}
+	_, err := io.WriteString(w, `]}`)
+	return err
+}
+
+func TestSendJSON_JSONMarshaler(t *testing.T) {
+	rr := httptest.NewRecorder()
+
+	err := SendJSON(rr, http.StatusOK, streamingPayload{ids: []int{1, 2, 3}})
+
+	assert.NoError(t, err, "SendJSON should use MarshalJSONTo")
+	assert.Equal(t, "{\"ids\":[1,2,3]}\n", rr.Body.String())
+}
+
+func TestSendJSON_JSONMarshalerError(t *testing.T) {
+	rr := httptest.NewRecorder()
+
+	err := SendJSON(rr, http.StatusOK, streamingPayload{err: errors.New("marshal failed")})
+
+	assert.Error(t, err, "MarshalJSONTo errors should be returned")
+	assert.Empty(t, rr.Header(), "Encoding failures should leave the response untouched")
+	assert.Zero(t, rr.Body.Len())
+}
+
+// spyResponseWriter records whether a handler touched the response at all.
+type spyResponseWriter struct {
+	header  http.Header
+	touched bool
+}
+
+func (s *spyResponseWriter) Header() http.Header {
+	s.touched = true
+	if s.header == nil {
+		s.header = http.Header{}
+	}
+	return s.header
+}
+
+func (s *spyResponseWriter) Write(b []byte) (int, error) {
+	s.touched = true
+	return len(b), nil
+}
+
+func (s *spyResponseWriter) WriteHeader(int) {
+	s.touched = true
+}
+
+func TestSendJSON_EncodeErrorWritesNothing(t *testing.T) {
+	unencodable := map[string]any{"ch": make(chan int)}
+	req := httptest.NewRequest("GET", "/", nil)
+	req.Header.Set("Accept-Encoding", "gzip")
+
+	w := &spyResponseWriter{}
+	assert.Error(t, SendJSON(w, http.StatusCreated, unencodable))
+	assert.False(t, w.touched, "SendJSON should not touch the ResponseWriter when encoding fails")
+
+	w = &spyResponseWriter{}
+	assert.Error(t, SendJSONWith(w, http.StatusOK, unencodable, WithIndent("", "  ")))
+	assert.False(t, w.touched, "SendJSONWith should not touch the ResponseWriter when encoding fails")
+
+	w = &spyResponseWriter{}
+	assert.Error(t, SendJSONWithETag(w, req, http.StatusOK, unencodable))
+	assert.False(t, w.touched)
+}
+
+func TestSendJSONRequest_Head(t *testing.T) {
+	data := map[string]string{"name": "Cassie"}
+
+	req := httptest.NewRequest("HEAD", "/", nil)
+	rr := httptest.NewRecorder()
+
+	err := SendJSONRequest(rr, req, http.StatusOK, data)
+
+	assert.NoError(t, err, "SendJSONRequest should not fail")
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, "application/json; charset=utf-8", rr.Header().Get("Content-Type"))
+	assert.Equal(t, strconv.Itoa(len("{\"name\":\"Cassie\"}\n")), rr.Header().Get("Content-Length"), "Content-Length should match the GET body")
+	assert.Empty(t, rr.Body.String(), "HEAD responses must not carry a body")
+
+	req = httptest.NewRequest("GET", "/", nil)
+	rr = httptest.NewRecorder()
+	assert.NoError(t, SendJSONRequest(rr, req, http.StatusOK, data))
+	assert.JSONEq(t, `{"name":"Cassie"}`, rr.Body.String(), "GET requests should receive the body")
+}
+
+func TestEncodeJSON(t *testing.T) {
+	var out bytes.Buffer
+
+	n, err := EncodeJSON(&out, map[string]string{"name": "Cassie"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "{\"name\":\"Cassie\"}\n", out.String())
+	assert.Equal(t, out.Len(), n, "EncodeJSON should report the bytes written")
+
+	out.Reset()
+	_, err = EncodeJSON(&out, streamingPayload{ids: []int{4, 2}})
+	assert.NoError(t, err)
+	assert.Equal(t, "{\"ids\":[4,2]}\n", out.String(), "EncodeJSON should use MarshalJSONTo")
+}
+
+func TestEncodeJSON_Errors(t *testing.T) {
+	var out bytes.Buffer
+	n, err := EncodeJSON(&out, make(chan int))
+	assert.Error(t, err)
+	assert.Zero(t, n)
+	assert.Zero(t, out.Len(), "Nothing should be written when encoding fails")
+
+	writeErr := errors.New("disk full")
+	_, err = EncodeJSON(failingWriter{err: writeErr}, "ok")
+	assert.Equal(t, writeErr, err, "Write errors should be returned")
+}
+
+// failingWriter is an io.Writer whose writes always fail.
+type failingWriter struct {
+	err error
+}
+
+func (f failingWriter) Write([]byte) (int, error) {
+	return 0, f.err
+}
@@ -0,0 +1,31 @@
+package helpers
+
+import (
+	"log"
+	"os"
+	"sync/atomic"
+)
+
+// defaultErrorLogger is used when no logger has been configured via SetErrorLogger.
+// It writes to stderr with a consistent prefix.
+var defaultErrorLogger = log.New(os.Stderr, "CASSIE HELPER ERROR: ", log.LstdFlags)
+
+// errorLogger holds the logger configured via SetErrorLogger, if any.
+var errorLogger atomic.Pointer[log.Logger]
+
+// SetErrorLogger routes helper-internal errors (e.g. a failed SendError write
+// or an unmapped error passed to SendErr) to logger.
+//
+// Passing nil restores the default stderr logger. It is safe to call
+// concurrently with the helpers.
+func SetErrorLogger(logger *log.Logger) {
+	errorLogger.Store(logger)
+}
+
+// getErrorLogger returns the configured error logger, or defaultErrorLogger.
+func getErrorLogger() *log.Logger {
+	if logger := errorLogger.Load(); logger != nil {
+		return logger
+	}
+	return defaultErrorLogger
+}
@@ -0,0 +1,24 @@
+package helpers
+
+import (
+	"bytes"
+	"errors"
+	"log"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetErrorLogger(t *testing.T) {
+	var out bytes.Buffer
+	SetErrorLogger(log.New(&out, "", 0))
+	defer SetErrorLogger(nil)
+
+	SendErr(httptest.NewRecorder(), errors.New("boom"))
+
+	assert.Contains(t, out.String(), "boom", "Helper errors should be routed to the configured logger")
+
+	SetErrorLogger(nil)
+	assert.Same(t, defaultErrorLogger, getErrorLogger(), "Passing nil should restore the default logger")
+}
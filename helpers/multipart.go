@@ -0,0 +1,30 @@
+package helpers
+
+import (
+	"errors"
+	"mime/multipart"
+	"net/http"
+)
+
+// ErrBodyTooLarge is returned when a request body exceeds the configured size limit.
+var ErrBodyTooLarge = errors.New("helpers: request body too large")
+
+// ParseMultipart parses a multipart/form-data request with explicit size limits.
+//
+// maxMemory caps how much of the form (including file parts) is held in
+// memory; the remainder of file parts spills to temporary files. maxTotal caps
+// the size of the entire request body: if it is exceeded, parsing stops and an
+// error wrapping ErrBodyTooLarge is returned, so the caller can respond 413.
+//
+// The returned form is also available as r.MultipartForm. Callers should call
+// form.RemoveAll when done to clean up temporary files.
+func ParseMultipart(r *http.Request, maxMemory, maxTotal int64) (*multipart.Form, error) {
+	if r.Body != nil {
+		r.Body = http.MaxBytesReader(nil, r.Body, maxTotal)
+	}
+
+	if err := r.ParseMultipartForm(maxMemory); err != nil {
+		return nil, bodyTooLarge(err)
+	}
+	return r.MultipartForm, nil
+}
@@ -0,0 +1,54 @@
+package helpers
+
+import (
+	"bytes"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// newMultipartRequest builds a multipart upload with a single file part of the given size.
+func newMultipartRequest(t *testing.T, fileSize int) *http.Request {
+	var body bytes.Buffer
+	mw := multipart.NewWriter(&body)
+	assert.NoError(t, mw.WriteField("title", "report"))
+	fw, err := mw.CreateFormFile("file", "report.txt")
+	assert.NoError(t, err)
+	_, err = fw.Write([]byte(strings.Repeat("x", fileSize)))
+	assert.NoError(t, err)
+	assert.NoError(t, mw.Close())
+
+	req := httptest.NewRequest("POST", "/upload", &body)
+	req.Header.Set("Content-Type", mw.FormDataContentType())
+	return req
+}
+
+func TestParseMultipart(t *testing.T) {
+	req := newMultipartRequest(t, 100)
+
+	form, err := ParseMultipart(req, 1<<10, 1<<20)
+
+	assert.NoError(t, err, "ParseMultipart should not fail within limits")
+	defer form.RemoveAll()
+	assert.Equal(t, []string{"report"}, form.Value["title"])
+	assert.Len(t, form.File["file"], 1)
+	assert.Equal(t, int64(100), form.File["file"][0].Size)
+}
+
+func TestParseMultipart_TooLarge(t *testing.T) {
+	req := newMultipartRequest(t, 10_000)
+
+	form, err := ParseMultipart(req, 1<<10, 1<<12)
+
+	assert.ErrorIs(t, err, ErrBodyTooLarge, "Oversized uploads should return ErrBodyTooLarge")
+	assert.Contains(t, err.Error(), "4096", "The error should mention the configured limit")
+	assert.Nil(t, form)
+
+	rr := httptest.NewRecorder()
+	SendErr(rr, err)
+	assert.Equal(t, http.StatusRequestEntityTooLarge, rr.Code, "ErrBodyTooLarge should map to 413")
+}
@@ -0,0 +1,88 @@
+package helpers
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/iameggi/cassie/bucket"
+)
+
+// DecodeNDJSON reads newline-delimited JSON (one value per line) from r and
+// calls fn with each record, without loading the whole payload into memory.
+//
+// Lines are read with a pooled scanner and buffer. Blank lines are skipped.
+// Decoding stops at the first error returned by fn, which is passed through
+// unchanged, or at the first line that is not exactly one valid JSON value,
+// reported with its 1-based line number. Lines may be at most
+// bufio.MaxScanTokenSize (64 KiB) long. If r is an http.MaxBytesReader whose
+// limit is exceeded, the returned error wraps ErrBodyTooLarge. A line cut
+// short by a read error is never passed to fn.
+//
+// The record passed to fn is only valid until fn returns, as its memory is
+// reused for the next line; unmarshal it or copy it to retain it.
+//
+// Example:
+//
+//	err := helpers.DecodeNDJSON(r.Body, func(rec json.RawMessage) error {
+//		var ev Event
+//		if err := json.Unmarshal(rec, &ev); err != nil {
+//			return err
+//		}
+//		return store.Insert(ctx, ev)
+//	})
+func DecodeNDJSON(r io.Reader, fn func(json.RawMessage) error) error {
+	src := &readErrRecorder{r: r}
+	return bucket.WithScanner(src, func(sc *bufio.Scanner) error {
+		// When reading fails, the scanner still hands out the partial last
+		// line, which must not reach fn even if it happens to be valid JSON.
+		unterminated := false
+		sc.Split(func(data []byte, atEOF bool) (int, []byte, error) {
+			advance, token, err := bufio.ScanLines(data, atEOF)
+			unterminated = atEOF && token != nil && bytes.IndexByte(data, '\n') < 0
+			return advance, token, err
+		})
+
+		line := 0
+		for sc.Scan() {
+			line++
+			if unterminated && src.err != nil {
+				return fmt.Errorf("helpers: NDJSON line %d: %w", line, bodyTooLarge(src.err))
+			}
+
+			record := bytes.TrimSpace(sc.Bytes())
+			if len(record) == 0 {
+				continue
+			}
+
+			if !json.Valid(record) {
+				// Only decode on the error path, to get a precise message.
+				return fmt.Errorf("helpers: NDJSON line %d: %w", line, json.Unmarshal(record, new(any)))
+			}
+			if err := fn(json.RawMessage(record)); err != nil {
+				return err
+			}
+		}
+
+		if err := sc.Err(); err != nil {
+			return fmt.Errorf("helpers: NDJSON line %d: %w", line+1, bodyTooLarge(err))
+		}
+		return nil
+	})
+}
+
+// readErrRecorder remembers the first error other than io.EOF returned by r.
+type readErrRecorder struct {
+	r   io.Reader
+	err error
+}
+
+func (rr *readErrRecorder) Read(p []byte) (int, error) {
+	n, err := rr.r.Read(p)
+	if err != nil && err != io.EOF && rr.err == nil {
+		rr.err = err
+	}
+	return n, err
+}
@@ -0,0 +1,88 @@
+package helpers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDecodeNDJSON(t *testing.T) {
+	input := "{\"id\":1}\n\n  {\"id\":2}  \r\n{\"id\":3}"
+
+	var ids []int
+	err := DecodeNDJSON(strings.NewReader(input), func(rec json.RawMessage) error {
+		var v struct {
+			ID int `json:"id"`
+		}
+		if err := json.Unmarshal(rec, &v); err != nil {
+			return err
+		}
+		ids = append(ids, v.ID)
+		return nil
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []int{1, 2, 3}, ids, "Blank lines and surrounding whitespace should be ignored")
+}
+
+func TestDecodeNDJSON_MalformedLine(t *testing.T) {
+	input := "{\"id\":1}\n{\"id\":\n{\"id\":3}\n"
+
+	var calls int
+	err := DecodeNDJSON(strings.NewReader(input), func(rec json.RawMessage) error {
+		calls++
+		return nil
+	})
+
+	assert.ErrorContains(t, err, "line 2")
+	var syntaxErr *json.SyntaxError
+	assert.ErrorAs(t, err, &syntaxErr)
+	assert.Equal(t, 1, calls, "Decoding should stop at the malformed line")
+
+	err = DecodeNDJSON(strings.NewReader("{} {}\n"), func(rec json.RawMessage) error { return nil })
+	assert.ErrorContains(t, err, "line 1", "Multiple values on one line should be rejected")
+}
+
+func TestDecodeNDJSON_CallbackError(t *testing.T) {
+	stop := errors.New("stop")
+
+	var calls int
+	err := DecodeNDJSON(strings.NewReader("1\n2\n3\n"), func(rec json.RawMessage) error {
+		calls++
+		if calls == 2 {
+			return stop
+		}
+		return nil
+	})
+
+	assert.Equal(t, stop, err, "Callback errors should be returned unchanged")
+	assert.Equal(t, 2, calls)
+}
+
+func TestDecodeNDJSON_BodyTooLarge(t *testing.T) {
+	req := httptest.NewRequest("POST", "/", strings.NewReader(strings.Repeat("{\"id\":1}\n", 100)))
+	body := http.MaxBytesReader(httptest.NewRecorder(), req.Body, 64)
+
+	err := DecodeNDJSON(body, func(rec json.RawMessage) error { return nil })
+	assert.ErrorIs(t, err, ErrBodyTooLarge)
+}
+
+func TestDecodeNDJSON_TruncatedValidLine(t *testing.T) {
+	// The limit cuts "12345678" to "123", which is still valid JSON.
+	req := httptest.NewRequest("POST", "/", strings.NewReader("1\n12345678\n"))
+	body := http.MaxBytesReader(httptest.NewRecorder(), req.Body, 5)
+
+	var records []string
+	err := DecodeNDJSON(body, func(rec json.RawMessage) error {
+		records = append(records, string(rec))
+		return nil
+	})
+
+	assert.ErrorIs(t, err, ErrBodyTooLarge)
+	assert.Equal(t, []string{"1"}, records, "The truncated line must not be passed to fn")
+}
@@ -0,0 +1,84 @@
+package helpers
+
+import (
+	"bytes"
+	"net/http"
+	"strconv"
+)
+
+// maxSafeInteger is the largest integer a JavaScript Number represents exactly (2^53 - 1).
+const maxSafeInteger = 1<<53 - 1
+
+// SendJSONStringNumbers behaves like SendJSON, but writes every integer whose
+// magnitude exceeds 2^53-1 as a quoted string, e.g. 9007199254740993 becomes
+// "9007199254740993".
+//
+// JavaScript clients parse JSON numbers as float64 and silently lose precision
+// on such values, which typically affects int64 IDs. This option changes the
+// wire format, so the client must expect string-typed IDs; values within the
+// safe range are left untouched. To quote specific fields unconditionally,
+// use the standard `json:",string"` tag option instead.
+func SendJSONStringNumbers(w http.ResponseWriter, statusCode int, data interface{}) error {
+	_, err := sendJSON(w, statusCode, data, jsonConfig{stringNumbers: true})
+	return err
+}
+
+// quoteUnsafeIntegers copies the JSON document src into dst, quoting integer
+// literals outside the JavaScript safe range. src should be valid JSON; an
+// unterminated string is copied through as is rather than read past the end.
+func quoteUnsafeIntegers(dst *bytes.Buffer, src []byte) {
+	for i := 0; i < len(src); {
+		c := src[i]
+		switch {
+		case c == '"':
+			// Copy strings verbatim, honoring escapes.
+			j := i + 1
+			for j < len(src) && src[j] != '"' {
+				if src[j] == '\\' {
+					j++
+				}
+				j++
+			}
+			j = min(j+1, len(src))
+			dst.Write(src[i:j])
+			i = j
+		case c == '-' || (c >= '0' && c <= '9'):
+			j := i + 1
+			for j < len(src) && isNumberByte(src[j]) {
+				j++
+			}
+			literal := src[i:j]
+			if isUnsafeInteger(literal) {
+				dst.WriteByte('"')
+				dst.Write(literal)
+				dst.WriteByte('"')
+			} else {
+				dst.Write(literal)
+			}
+			i = j
+		default:
+			dst.WriteByte(c)
+			i++
+		}
+	}
+}
+
+// isNumberByte reports whether c can appear inside a JSON number literal.
+func isNumberByte(c byte) bool {
+	return (c >= '0' && c <= '9') || c == '-' || c == '+' || c == '.' || c == 'e' || c == 'E'
+}
+
+// isUnsafeInteger reports whether literal is an integer beyond ±(2^53-1).
+func isUnsafeInteger(literal []byte) bool {
+	digits := bytes.TrimPrefix(literal, []byte("-"))
+	if len(digits) < 16 { // 2^53-1 has 16 digits.
+		return false
+	}
+	for _, c := range digits {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	n, err := strconv.ParseUint(string(digits), 10, 64)
+	return err != nil || n > maxSafeInteger
+}
@@ -0,0 +1,87 @@
+package helpers
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSendJSONStringNumbers(t *testing.T) {
+	type payload struct {
+		ID     int64   `json:"id"`
+		Neg    int64   `json:"neg"`
+		Small  int     `json:"small"`
+		Safe   int64   `json:"safe"`
+		Ratio  float64 `json:"ratio"`
+		Note   string  `json:"note"`
+		Values []int64 `json:"values"`
+	}
+	data := payload{
+		ID:     9007199254740993,
+		Neg:    -9223372036854775808,
+		Small:  42,
+		Safe:   9007199254740991,
+		Ratio:  1.5,
+		Note:   `id "9007199254740993" \ 12345678901234567`,
+		Values: []int64{1, 1 << 60},
+	}
+
+	rr := httptest.NewRecorder()
+	err := SendJSONStringNumbers(rr, http.StatusOK, data)
+
+	assert.NoError(t, err, "SendJSONStringNumbers should not fail")
+	expectedJSON := `{
+		"id":"9007199254740993",
+		"neg":"-9223372036854775808",
+		"small":42,
+		"safe":9007199254740991,
+		"ratio":1.5,
+		"note":"id \"9007199254740993\" \\ 12345678901234567",
+		"values":[1,"1152921504606846976"]
+	}`
+	assert.JSONEq(t, expectedJSON, rr.Body.String(), "Only unsafe integers should be quoted")
+}
+
+func TestQuoteUnsafeIntegers_Exponent(t *testing.T) {
+	var dst bytes.Buffer
+	quoteUnsafeIntegers(&dst, []byte(`[1e+21,12345678901234567.5]`))
+
+	assert.Equal(t, `[1e+21,12345678901234567.5]`, dst.String(), "Non-integer literals should be left untouched")
+}
+
+func TestQuoteUnsafeIntegers_UnterminatedString(t *testing.T) {
+	var dst bytes.Buffer
+	assert.NotPanics(t, func() {
+		quoteUnsafeIntegers(&dst, []byte(`{"a":"unterminated`))
+	})
+	assert.Equal(t, `{"a":"unterminated`, dst.String())
+
+	dst.Reset()
+	quoteUnsafeIntegers(&dst, []byte(`"ends in escape\`))
+	assert.Equal(t, `"ends in escape\`, dst.String())
+}
+
+// rawMarshaler writes its bytes verbatim from MarshalJSONTo.
+type rawMarshaler string
+
+func (m rawMarshaler) MarshalJSONTo(w io.Writer) error {
+	_, err := io.WriteString(w, string(m))
+	return err
+}
+
+func TestSendJSONStringNumbers_MalformedMarshaler(t *testing.T) {
+	rr := httptest.NewRecorder()
+
+	var err error
+	assert.NotPanics(t, func() {
+		err = SendJSONStringNumbers(rr, http.StatusOK, rawMarshaler(`{"id":12345678901234567,"name":"oops`))
+	})
+
+	assert.Error(t, err, "Invalid marshaler output should be reported")
+	assert.Empty(t, rr.Header().Get("Content-Type"), "Nothing should be written on failure")
+	assert.Zero(t, rr.Body.Len())
+}
@@ -0,0 +1,34 @@
+package helpers
+
+import "net/http"
+
+// Pagination describes the paging metadata of a list response.
+// TotalPages is computed by SendPage from Total and PerPage.
+type Pagination struct {
+	Page       int `json:"page"`
+	PerPage    int `json:"per_page"`
+	Total      int `json:"total"`
+	TotalPages int `json:"total_pages"`
+}
+
+// SendPage sends a paginated list response using the pooled SendJSON path.
+//
+// The response body has the form:
+//
+//	{"data":[...],"meta":{"page":1,"per_page":20,"total":42,"total_pages":3}}
+//
+// page.TotalPages is always recomputed from Total and PerPage (it is 0 when
+// PerPage is not positive).
+func SendPage(w http.ResponseWriter, statusCode int, items any, page Pagination) error {
+	type pageResponse struct {
+		Data any        `json:"data"`
+		Meta Pagination `json:"meta"`
+	}
+
+	page.TotalPages = 0
+	if page.PerPage > 0 {
+		page.TotalPages = (page.Total + page.PerPage - 1) / page.PerPage
+	}
+
+	return SendJSON(w, statusCode, pageResponse{Data: items, Meta: page})
+}
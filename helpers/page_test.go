@@ -0,0 +1,30 @@
+package helpers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSendPage(t *testing.T) {
+	rr := httptest.NewRecorder()
+
+	err := SendPage(rr, http.StatusOK, []string{"a", "b"}, Pagination{Page: 2, PerPage: 2, Total: 5})
+
+	assert.NoError(t, err, "SendPage should not fail")
+	assert.Equal(t, http.StatusOK, rr.Code)
+
+	expectedJSON := `{"data":["a","b"],"meta":{"page":2,"per_page":2,"total":5,"total_pages":3}}`
+	assert.JSONEq(t, expectedJSON, rr.Body.String(), "Page envelope does not match expected value")
+}
+
+func TestSendPage_ZeroPerPage(t *testing.T) {
+	rr := httptest.NewRecorder()
+
+	err := SendPage(rr, http.StatusOK, []string{}, Pagination{Page: 1, Total: 5})
+
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"data":[],"meta":{"page":1,"per_page":0,"total":5,"total_pages":0}}`, rr.Body.String(), "TotalPages should be 0 without a page size")
+}
@@ -0,0 +1,58 @@
+package helpers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+)
+
+// ErrMissingQuery is returned by the strict query readers when the parameter is absent.
+var ErrMissingQuery = errors.New("helpers: missing query parameter")
+
+// QueryString returns the first value of the query parameter key,
+// or def if the parameter is missing or empty.
+func QueryString(r *http.Request, key string, def string) string {
+	if v := r.URL.Query().Get(key); v != "" {
+		return v
+	}
+	return def
+}
+
+// QueryInt returns the query parameter key parsed as an int,
+// or def if the parameter is missing or not a valid integer.
+func QueryInt(r *http.Request, key string, def int) int {
+	n, err := strconv.Atoi(r.URL.Query().Get(key))
+	if err != nil {
+		return def
+	}
+	return n
+}
+
+// QueryBool returns the query parameter key parsed with strconv.ParseBool,
+// or def if the parameter is missing or not a valid boolean.
+func QueryBool(r *http.Request, key string, def bool) bool {
+	b, err := strconv.ParseBool(r.URL.Query().Get(key))
+	if err != nil {
+		return def
+	}
+	return b
+}
+
+// QueryIntRequired returns the query parameter key parsed as an int.
+//
+// Unlike QueryInt it does not fall back to a default: a missing parameter
+// yields an error wrapping ErrMissingQuery, and an invalid one yields a
+// *FieldError, so the caller can respond with a 400.
+func QueryIntRequired(r *http.Request, key string) (int, error) {
+	raw := r.URL.Query().Get(key)
+	if raw == "" {
+		return 0, fmt.Errorf("%w: %q", ErrMissingQuery, key)
+	}
+
+	n, err := strconv.Atoi(raw)
+	if err != nil {
+		return 0, &FieldError{Field: key, Expected: "int", Value: raw}
+	}
+	return n, nil
+}
@@ -0,0 +1,39 @@
+package helpers
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQueryReaders(t *testing.T) {
+	req := httptest.NewRequest("GET", "/?name=cassie&page=3&bad=x&active=true", nil)
+
+	assert.Equal(t, "cassie", QueryString(req, "name", "anon"))
+	assert.Equal(t, "anon", QueryString(req, "missing", "anon"), "Missing strings should fall back to the default")
+
+	assert.Equal(t, 3, QueryInt(req, "page", 1))
+	assert.Equal(t, 1, QueryInt(req, "missing", 1), "Missing ints should fall back to the default")
+	assert.Equal(t, 1, QueryInt(req, "bad", 1), "Invalid ints should fall back to the default")
+
+	assert.True(t, QueryBool(req, "active", false))
+	assert.True(t, QueryBool(req, "missing", true), "Missing bools should fall back to the default")
+	assert.False(t, QueryBool(req, "bad", false), "Invalid bools should fall back to the default")
+}
+
+func TestQueryIntRequired(t *testing.T) {
+	req := httptest.NewRequest("GET", "/?page=3&bad=x", nil)
+
+	n, err := QueryIntRequired(req, "page")
+	assert.NoError(t, err)
+	assert.Equal(t, 3, n)
+
+	_, err = QueryIntRequired(req, "missing")
+	assert.ErrorIs(t, err, ErrMissingQuery, "Missing parameters should wrap ErrMissingQuery")
+
+	_, err = QueryIntRequired(req, "bad")
+	var fieldErr *FieldError
+	assert.ErrorAs(t, err, &fieldErr, "Invalid parameters should be reported as *FieldError")
+	assert.Equal(t, "bad", fieldErr.Field)
+}
@@ -0,0 +1,29 @@
+package helpers
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+
+	"github.com/iameggi/cassie/bucket"
+)
+
+// SendJSONRaw streams pre-encoded JSON from r to the client.
+//
+// Use it for pass-through payloads (from a cache or an upstream service)
+// to avoid a decode/encode round-trip. The content is not validated.
+// The Content-Type header and status code are written before copying,
+// using a pooled buffer's storage as the copy buffer.
+//
+// Returns an error if reading from r or writing to the client fails.
+func SendJSONRaw(w http.ResponseWriter, statusCode int, r io.Reader) error {
+	return bucket.WithByteBufferErr(func(buf *bytes.Buffer) error {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(statusCode)
+
+		// Borrow the pooled buffer's spare capacity as scratch space for the copy.
+		scratch := buf.AvailableBuffer()
+		_, err := io.CopyBuffer(w, r, scratch[:cap(scratch)])
+		return err
+	})
+}
@@ -0,0 +1,33 @@
+package helpers
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSendJSONRaw(t *testing.T) {
+	payload := `{"cached":true,"items":[1,2,3]}`
+	rr := httptest.NewRecorder()
+
+	err := SendJSONRaw(rr, http.StatusOK, strings.NewReader(payload))
+
+	assert.NoError(t, err, "SendJSONRaw should not fail")
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, "application/json; charset=utf-8", rr.Header().Get("Content-Type"), "Incorrect Content-Type header")
+	assert.Equal(t, payload, rr.Body.String(), "Payload should be passed through unchanged")
+}
+
+func TestSendJSONRaw_ReadError(t *testing.T) {
+	readErr := errors.New("upstream reset")
+	rr := httptest.NewRecorder()
+
+	err := SendJSONRaw(rr, http.StatusOK, iotest.ErrReader(readErr))
+
+	assert.ErrorIs(t, err, readErr, "Read errors should be returned")
+}
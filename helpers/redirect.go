@@ -0,0 +1,31 @@
+package helpers
+
+import "net/http"
+
+// SendRedirect redirects the client to url with a status code chosen to
+// preserve the request method where it matters.
+//
+// GET and HEAD requests receive the classic codes (301 when permanent,
+// 302 otherwise), which every client understands. Any other method receives
+// 308 or 307, which forbid the client from rewriting the request as a GET,
+// so the method and body are replayed against the new location.
+//
+// The Location header is set and the response body is left empty.
+func SendRedirect(w http.ResponseWriter, r *http.Request, url string, permanent bool) {
+	safe := r.Method == http.MethodGet || r.Method == http.MethodHead
+
+	var statusCode int
+	switch {
+	case permanent && safe:
+		statusCode = http.StatusMovedPermanently
+	case permanent:
+		statusCode = http.StatusPermanentRedirect
+	case safe:
+		statusCode = http.StatusFound
+	default:
+		statusCode = http.StatusTemporaryRedirect
+	}
+
+	w.Header().Set("Location", url)
+	w.WriteHeader(statusCode)
+}
@@ -0,0 +1,35 @@
+package helpers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSendRedirect(t *testing.T) {
+	cases := []struct {
+		method    string
+		permanent bool
+		expected  int
+	}{
+		{http.MethodGet, true, http.StatusMovedPermanently},
+		{http.MethodHead, true, http.StatusMovedPermanently},
+		{http.MethodPost, true, http.StatusPermanentRedirect},
+		{http.MethodGet, false, http.StatusFound},
+		{http.MethodHead, false, http.StatusFound},
+		{http.MethodPut, false, http.StatusTemporaryRedirect},
+	}
+
+	for _, tc := range cases {
+		req := httptest.NewRequest(tc.method, "/old", nil)
+		rr := httptest.NewRecorder()
+
+		SendRedirect(rr, req, "/new", tc.permanent)
+
+		assert.Equal(t, tc.expected, rr.Code, "Unexpected status for %s (permanent=%v)", tc.method, tc.permanent)
+		assert.Equal(t, "/new", rr.Header().Get("Location"), "Location header should be set")
+		assert.Empty(t, rr.Body.String(), "Redirects should have an empty body")
+	}
+}
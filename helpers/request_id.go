@@ -0,0 +1,37 @@
+package helpers
+
+import "net/http"
+
+// contextKey is the type of the context keys exported by this package.
+type contextKey struct {
+	name string
+}
+
+func (k *contextKey) String() string {
+	return "helpers context key " + k.name
+}
+
+// RequestIDKey is the context key under which the request's ID is stored,
+// as a string. middleware.RequestID sets it; middleware.RequestIDKey is the
+// same key, declared here so that helpers can read it without importing
+// middleware.
+var RequestIDKey = &contextKey{"request_id"}
+
+// SendErrorWithRequest behaves like SendError, but also includes the ID
+// assigned to r by middleware.RequestID, so clients can quote it in support
+// requests:
+//
+//	{"error":"User not found","request_id":"6f1c..."}
+//
+// The request_id field is omitted when r carries no ID.
+func SendErrorWithRequest(w http.ResponseWriter, r *http.Request, statusCode int, message string) {
+	type errorResponse struct {
+		Error     string `json:"error"`
+		RequestID string `json:"request_id,omitempty"`
+	}
+
+	id, _ := r.Context().Value(RequestIDKey).(string)
+	if err := SendJSON(w, statusCode, errorResponse{Error: message, RequestID: id}); err != nil {
+		getErrorLogger().Printf("failed to send SendErrorWithRequest response: %v", err)
+	}
+}
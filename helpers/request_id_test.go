@@ -0,0 +1,31 @@
+package helpers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSendErrorWithRequest(t *testing.T) {
+	r := httptest.NewRequest("GET", "/", nil)
+	r = r.WithContext(context.WithValue(r.Context(), RequestIDKey, "abc123"))
+	rr := httptest.NewRecorder()
+
+	SendErrorWithRequest(rr, r, http.StatusNotFound, "User not found")
+
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+	assert.Equal(t, "application/json; charset=utf-8", rr.Header().Get("Content-Type"))
+	assert.JSONEq(t, `{"error":"User not found","request_id":"abc123"}`, rr.Body.String())
+}
+
+func TestSendErrorWithRequest_NoID(t *testing.T) {
+	rr := httptest.NewRecorder()
+
+	SendErrorWithRequest(rr, httptest.NewRequest("GET", "/", nil), http.StatusBadRequest, "bad input")
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	assert.JSONEq(t, `{"error":"bad input"}`, rr.Body.String(), "request_id should be omitted")
+}
@@ -0,0 +1,32 @@
+package helpers
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// SendRetryError behaves like SendError, but also tells the client when to
+// try again via a Retry-After header, given in whole seconds (rounded up).
+//
+// Retry-After is only meaningful for 429 Too Many Requests and
+// 503 Service Unavailable. For any other status the header is omitted, the
+// misuse is logged using the logger configured with SetErrorLogger, and the
+// error body is still sent.
+//
+// Example:
+//
+//	helpers.SendRetryError(w, http.StatusTooManyRequests, "rate limit exceeded", 30*time.Second)
+func SendRetryError(w http.ResponseWriter, statusCode int, message string, retryAfter time.Duration) {
+	if statusCode == http.StatusTooManyRequests || statusCode == http.StatusServiceUnavailable {
+		seconds := (retryAfter + time.Second - 1) / time.Second
+		if seconds < 0 {
+			seconds = 0
+		}
+		w.Header().Set("Retry-After", strconv.FormatInt(int64(seconds), 10))
+	} else {
+		getErrorLogger().Printf("SendRetryError: Retry-After is not meaningful for status %d; header omitted", statusCode)
+	}
+
+	SendError(w, statusCode, message)
+}
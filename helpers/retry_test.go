@@ -0,0 +1,47 @@
+package helpers
+
+import (
+	"bytes"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSendRetryError(t *testing.T) {
+	tests := []struct {
+		status     int
+		retryAfter time.Duration
+		want       string
+	}{
+		{http.StatusTooManyRequests, 30 * time.Second, "30"},
+		{http.StatusServiceUnavailable, 1500 * time.Millisecond, "2"},
+		{http.StatusServiceUnavailable, -time.Second, "0"},
+	}
+
+	for _, tt := range tests {
+		rr := httptest.NewRecorder()
+		SendRetryError(rr, tt.status, "slow down", tt.retryAfter)
+
+		assert.Equal(t, tt.status, rr.Code)
+		assert.Equal(t, tt.want, rr.Header().Get("Retry-After"), "Retry-After for %v", tt.retryAfter)
+		assert.JSONEq(t, `{"error":"slow down"}`, rr.Body.String())
+	}
+}
+
+func TestSendRetryError_InvalidStatus(t *testing.T) {
+	var out bytes.Buffer
+	SetErrorLogger(log.New(&out, "", 0))
+	defer SetErrorLogger(nil)
+
+	rr := httptest.NewRecorder()
+	SendRetryError(rr, http.StatusBadRequest, "bad input", time.Minute)
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	assert.Empty(t, rr.Header().Get("Retry-After"), "Retry-After should only be sent for 429 and 503")
+	assert.JSONEq(t, `{"error":"bad input"}`, rr.Body.String())
+	assert.Contains(t, out.String(), "status 400", "Misuse should be logged")
+}
@@ -0,0 +1,104 @@
+package helpers
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/iameggi/cassie/bucket"
+)
+
+// ErrFlushNotSupported is returned by NewSSEWriter when the ResponseWriter
+// cannot flush, which makes server-sent events impossible.
+var ErrFlushNotSupported = errors.New("helpers: response writer does not support flushing")
+
+// SSEWriter streams server-sent events to a client.
+// It is safe for concurrent use by multiple goroutines.
+type SSEWriter struct {
+	mu      sync.Mutex
+	w       http.ResponseWriter
+	flusher http.Flusher
+}
+
+// NewSSEWriter prepares w for server-sent events.
+//
+// It verifies that w implements http.Flusher, sets the text/event-stream
+// headers, writes a 200 status, and flushes so the client sees the stream
+// open immediately. Returns ErrFlushNotSupported (without touching w) if
+// flushing is not available.
+//
+// Example:
+//
+//	sse, err := helpers.NewSSEWriter(w)
+//	if err != nil {
+//		helpers.SendError(w, http.StatusInternalServerError, "streaming unsupported")
+//		return
+//	}
+//	for msg := range updates {
+//		if err := sse.Send("update", msg); err != nil {
+//			return
+//		}
+//	}
+func NewSSEWriter(w http.ResponseWriter) (*SSEWriter, error) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		return nil, ErrFlushNotSupported
+	}
+
+	h := w.Header()
+	h.Set("Content-Type", "text/event-stream")
+	h.Set("Cache-Control", "no-cache")
+	h.Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	return &SSEWriter{w: w, flusher: flusher}, nil
+}
+
+// Send writes a single event and flushes it to the client.
+//
+// If event is empty, the "event:" field is omitted and clients receive a
+// default "message" event. Multi-line data is split into several "data:"
+// fields, as required by the SSE format; "\r\n", "\r", and "\n" all end a
+// line. An event name containing a line break is rejected with an error, as
+// it could inject extra fields into the stream.
+func (s *SSEWriter) Send(event, data string) error {
+	if strings.ContainsAny(event, "\r\n") {
+		return errors.New("helpers: SSE event name must not contain CR or LF")
+	}
+
+	return bucket.WithByteBufferErr(func(buf *bytes.Buffer) error {
+		if event != "" {
+			buf.WriteString("event: ")
+			buf.WriteString(event)
+			buf.WriteByte('\n')
+		}
+		for _, line := range sseLines(data) {
+			buf.WriteString("data: ")
+			buf.WriteString(line)
+			buf.WriteByte('\n')
+		}
+		buf.WriteByte('\n')
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		if _, err := s.w.Write(buf.Bytes()); err != nil {
+			return err
+		}
+		s.flusher.Flush()
+		return nil
+	})
+}
+
+// sseLines splits data into lines as an SSE client would, on "\r\n", "\r",
+// or "\n".
+func sseLines(data string) []string {
+	if strings.ContainsRune(data, '\r') {
+		data = strings.ReplaceAll(data, "\r\n", "\n")
+		data = strings.ReplaceAll(data, "\r", "\n")
+	}
+	return strings.Split(data, "\n")
+}
@@ -0,0 +1,60 @@
+package helpers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// nonFlusher is a ResponseWriter that hides the recorder's Flush method.
+type nonFlusher struct {
+	http.ResponseWriter
+}
+
+func TestSSEWriter(t *testing.T) {
+	rr := httptest.NewRecorder()
+
+	sse, err := NewSSEWriter(rr)
+	assert.NoError(t, err, "NewSSEWriter should not fail for a flushable writer")
+	assert.Equal(t, "text/event-stream", rr.Header().Get("Content-Type"))
+	assert.Equal(t, "no-cache", rr.Header().Get("Cache-Control"))
+	assert.Equal(t, "keep-alive", rr.Header().Get("Connection"))
+
+	assert.NoError(t, sse.Send("update", "line1\nline2"))
+	assert.NoError(t, sse.Send("", "plain"))
+
+	assert.True(t, rr.Flushed, "Events should be flushed")
+	assert.Equal(t, "event: update\ndata: line1\ndata: line2\n\ndata: plain\n\n", rr.Body.String())
+}
+
+func TestSSEWriter_NoFlusher(t *testing.T) {
+	rr := httptest.NewRecorder()
+
+	sse, err := NewSSEWriter(nonFlusher{rr})
+
+	assert.ErrorIs(t, err, ErrFlushNotSupported)
+	assert.Nil(t, sse)
+	assert.Empty(t, rr.Header().Get("Content-Type"), "Headers should not be touched on failure")
+}
+
+func TestSSEWriter_RejectsLineBreaksInEvent(t *testing.T) {
+	rr := httptest.NewRecorder()
+	sse, err := NewSSEWriter(rr)
+	assert.NoError(t, err)
+	opened := rr.Body.Len()
+
+	assert.Error(t, sse.Send("update\nid: 42", "x"), "LF in the event name should be rejected")
+	assert.Error(t, sse.Send("update\rretry: 1", "x"), "CR in the event name should be rejected")
+	assert.Equal(t, opened, rr.Body.Len(), "Nothing should be written for rejected events")
+}
+
+func TestSSEWriter_SplitsAllLineEndings(t *testing.T) {
+	rr := httptest.NewRecorder()
+	sse, err := NewSSEWriter(rr)
+	assert.NoError(t, err)
+
+	assert.NoError(t, sse.Send("", "a\r\nb\rc\nd"))
+	assert.Equal(t, "data: a\ndata: b\ndata: c\ndata: d\n\n", rr.Body.String())
+}
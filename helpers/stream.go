@@ -0,0 +1,84 @@
+package helpers
+
+import (
+	"bytes"
+	"encoding/json"
+	"iter"
+	"net/http"
+
+	"github.com/iameggi/cassie/bucket"
+)
+
+// streamFlushThreshold is the number of buffered bytes after which
+// StreamJSONArray writes its pending output and flushes it to the client.
+const streamFlushThreshold = bucket.DefaultCapacity
+
+// StreamJSONArray writes items as a JSON array without materializing the
+// whole result set in memory.
+//
+// Each element is encoded into a pooled *bytes.Buffer; whenever the buffer
+// grows past a few kilobytes it is written to the client and, if the
+// ResponseWriter supports it, flushed. This keeps memory bounded for very
+// large responses.
+//
+// Because the status line is sent before the first element is encoded, an
+// encoding error mid-stream cannot be reported with a different status code.
+// In that case the array is left unterminated, so the client sees invalid
+// JSON rather than a silently truncated result, and the error is returned.
+//
+// Example:
+//
+//	helpers.StreamJSONArray(w, http.StatusOK, slices.Values(rows))
+func StreamJSONArray[T any](w http.ResponseWriter, statusCode int, items iter.Seq[T]) error {
+	flusher, _ := w.(http.Flusher)
+
+	return bucket.WithByteBufferErr(func(buf *bytes.Buffer) error {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(statusCode)
+
+		// flush writes any pending output to the client.
+		flush := func() error {
+			if _, err := w.Write(buf.Bytes()); err != nil {
+				return err
+			}
+			buf.Reset()
+			if flusher != nil {
+				flusher.Flush()
+			}
+			return nil
+		}
+
+		enc := json.NewEncoder(buf)
+		buf.WriteByte('[')
+
+		var err error
+		first := true
+		for item := range items {
+			if !first {
+				buf.WriteByte(',')
+			}
+			first = false
+
+			if err = enc.Encode(item); err != nil {
+				break
+			}
+			// Drop the trailing newline added by Encode to keep the array compact.
+			buf.Truncate(buf.Len() - 1)
+
+			if buf.Len() >= streamFlushThreshold {
+				if err = flush(); err != nil {
+					break
+				}
+			}
+		}
+
+		if err != nil {
+			// Send what was encoded so far; the missing ']' marks the response as broken.
+			_ = flush()
+			return err
+		}
+
+		buf.WriteString("]\n")
+		return flush()
+	})
+}
@@ -0,0 +1,55 @@
+package helpers
+
+import (
+	"encoding/json"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStreamJSONArray(t *testing.T) {
+	type row struct {
+		ID int `json:"id"`
+	}
+	rows := make([]row, 5000)
+	for i := range rows {
+		rows[i] = row{ID: i}
+	}
+
+	rr := httptest.NewRecorder()
+
+	err := StreamJSONArray(rr, http.StatusOK, slices.Values(rows))
+
+	assert.NoError(t, err, "StreamJSONArray should not fail")
+	assert.Equal(t, http.StatusOK, rr.Code, "Status code should be 200 OK")
+	assert.Equal(t, "application/json; charset=utf-8", rr.Header().Get("Content-Type"), "Incorrect Content-Type header")
+	assert.True(t, rr.Flushed, "Large responses should be flushed while streaming")
+
+	var decoded []row
+	assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &decoded), "Response body should be a valid JSON array")
+	assert.Equal(t, rows, decoded, "Streamed array does not match input data")
+}
+
+func TestStreamJSONArray_Empty(t *testing.T) {
+	rr := httptest.NewRecorder()
+
+	err := StreamJSONArray(rr, http.StatusOK, slices.Values([]int{}))
+
+	assert.NoError(t, err)
+	assert.JSONEq(t, `[]`, rr.Body.String(), "Empty sequence should produce an empty array")
+}
+
+func TestStreamJSONArray_EncodeError(t *testing.T) {
+	rr := httptest.NewRecorder()
+
+	err := StreamJSONArray(rr, http.StatusOK, slices.Values([]float64{1, math.Inf(1)}))
+
+	assert.Error(t, err, "Unsupported values should surface an error")
+	assert.Equal(t, http.StatusOK, rr.Code, "Status is already committed when streaming starts")
+	assert.False(t, strings.HasSuffix(strings.TrimSpace(rr.Body.String()), "]"), "Broken stream must not look like a complete array")
+}
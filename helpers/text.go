@@ -0,0 +1,24 @@
+package helpers
+
+import (
+	"io"
+	"net/http"
+)
+
+// SendText writes a plain-text response.
+//
+// It sets the Content-Type header to text/plain, writes the status code,
+// and writes text as-is. The string is written directly to the client, so
+// no intermediate buffer is needed. Useful for health checks, debug
+// endpoints, and webhook acknowledgements.
+//
+// Returns an error if writing to the client fails.
+func SendText(w http.ResponseWriter, statusCode int, text string) error {
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.WriteHeader(statusCode)
+
+	if _, err := io.WriteString(w, text); err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,20 @@
+package helpers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSendText(t *testing.T) {
+	rr := httptest.NewRecorder()
+
+	err := SendText(rr, http.StatusAccepted, "ok")
+
+	assert.NoError(t, err, "SendText should not fail")
+	assert.Equal(t, http.StatusAccepted, rr.Code, "Status code should be 202 Accepted")
+	assert.Equal(t, "text/plain; charset=utf-8", rr.Header().Get("Content-Type"), "Incorrect Content-Type header")
+	assert.Equal(t, "ok", rr.Body.String())
+}
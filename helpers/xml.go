@@ -0,0 +1,37 @@
+package helpers
+
+import (
+	"bytes"
+	"encoding/xml"
+	"net/http"
+
+	"github.com/iameggi/cassie/bucket"
+)
+
+// SendXML writes an XML response using Cassie's pooled buffers.
+//
+// It mirrors SendJSON for legacy integrations that cannot consume JSON.
+// The standard XML declaration is prepended to the encoded document and
+// the Content-Type header is set to application/xml.
+//
+// Returns an error if XML encoding or writing to the client fails.
+func SendXML(w http.ResponseWriter, statusCode int, data interface{}) error {
+	return bucket.WithByteBufferErr(func(buf *bytes.Buffer) error {
+		// Prepend the XML declaration, then encode directly into the pooled buffer.
+		buf.WriteString(xml.Header)
+		if err := xml.NewEncoder(buf).Encode(data); err != nil {
+			http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+			return err
+		}
+
+		// Write headers and response body.
+		w.Header().Set("Content-Type", "application/xml; charset=utf-8")
+		w.WriteHeader(statusCode)
+
+		if _, err := w.Write(buf.Bytes()); err != nil {
+			// Handle client write errors (e.g., broken pipe).
+			return err
+		}
+		return nil
+	})
+}
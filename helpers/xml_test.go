@@ -0,0 +1,45 @@
+package helpers
+
+import (
+	"encoding/xml"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSendXML(t *testing.T) {
+	type testData struct {
+		XMLName xml.Name `xml:"user"`
+		ID      int      `xml:"id"`
+		Name    string   `xml:"name"`
+	}
+	data := testData{ID: 1, Name: "Cassie"}
+
+	rr := httptest.NewRecorder()
+
+	err := SendXML(rr, http.StatusOK, data)
+
+	assert.NoError(t, err, "SendXML should not fail")
+	assert.Equal(t, http.StatusOK, rr.Code, "Status code should be 200 OK")
+	assert.Equal(t, "application/xml; charset=utf-8", rr.Header().Get("Content-Type"), "Incorrect Content-Type header")
+	assert.True(t, strings.HasPrefix(rr.Body.String(), xml.Header), "Response should start with the XML declaration")
+
+	var responseData testData
+	err = xml.Unmarshal(rr.Body.Bytes(), &responseData)
+
+	assert.NoError(t, err, "Response body should be valid XML")
+	assert.Equal(t, data.Name, responseData.Name, "Response XML body does not match input data")
+	assert.Equal(t, data.ID, responseData.ID, "Response XML body does not match input data")
+}
+
+func TestSendXML_EncodeError(t *testing.T) {
+	rr := httptest.NewRecorder()
+
+	err := SendXML(rr, http.StatusOK, map[string]string{"unsupported": "map"})
+
+	assert.Error(t, err, "SendXML should fail for types XML cannot encode")
+	assert.Equal(t, http.StatusInternalServerError, rr.Code, "Status code should be 500 on encode failure")
+}
@@ -0,0 +1,243 @@
+package middleware
+
+import (
+	"bytes"
+	"container/list"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/iameggi/cassie/bucket"
+)
+
+// CacheOptions configures the Cache middleware.
+type CacheOptions struct {
+	// TTL is how long a cached response is served before the handler is
+	// invoked again. Must be greater than zero.
+	TTL time.Duration
+
+	// MaxBytes caps the total size of the cached response bodies. When adding
+	// a response would exceed it, the least recently used entries are evicted.
+	// Responses larger than MaxBytes are never cached. Must be greater than zero.
+	MaxBytes int
+}
+
+// cacheEntry is a stored response.
+type cacheEntry struct {
+	key     string
+	status  int
+	header  http.Header
+	body    []byte
+	expires time.Time
+}
+
+// responseCache is a size-bounded LRU of responses, safe for concurrent use.
+type responseCache struct {
+	mu       sync.Mutex
+	entries  map[string]*list.Element
+	lru      *list.List // Front is most recently used.
+	size     int
+	maxBytes int
+}
+
+// get returns the live entry for key, evicting it if it has expired.
+func (c *responseCache) get(key string, now time.Time) (*cacheEntry, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	elem, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+
+	entry := elem.Value.(*cacheEntry)
+	if !now.Before(entry.expires) {
+		c.remove(elem)
+		return nil, false
+	}
+
+	c.lru.MoveToFront(elem)
+	return entry, true
+}
+
+// add stores entry, replacing any previous entry for the same key and
+// evicting least recently used entries until it fits.
+func (c *responseCache) add(entry *cacheEntry) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if elem, ok := c.entries[entry.key]; ok {
+		c.remove(elem)
+	}
+	for c.size+len(entry.body) > c.maxBytes && c.lru.Len() > 0 {
+		c.remove(c.lru.Back())
+	}
+
+	c.entries[entry.key] = c.lru.PushFront(entry)
+	c.size += len(entry.body)
+}
+
+// remove drops elem from the cache. The caller must hold c.mu.
+func (c *responseCache) remove(elem *list.Element) {
+	entry := c.lru.Remove(elem).(*cacheEntry)
+	delete(c.entries, entry.key)
+	c.size -= len(entry.body)
+}
+
+// cacheRecorder passes a response through to the client while keeping a copy
+// of its status, headers, and body.
+type cacheRecorder struct {
+	http.ResponseWriter
+	statusCode  int
+	header      http.Header // Snapshot taken when the header is written.
+	body        *bytes.Buffer
+	maxBytes    int  // Capturing stops once the body grows past this size.
+	skip        bool // The response turned out uncacheable while being written.
+	wroteHeader bool
+}
+
+// WriteHeader records the status code and a snapshot of the headers.
+func (c *cacheRecorder) WriteHeader(code int) {
+	c.recordHeader(code)
+	c.ResponseWriter.WriteHeader(code)
+}
+
+// recordHeader records the status code and a snapshot of the headers, once.
+func (c *cacheRecorder) recordHeader(code int) {
+	if !c.wroteHeader {
+		c.wroteHeader = true
+		c.statusCode = code
+		c.header = c.ResponseWriter.Header().Clone()
+	}
+}
+
+// Write copies p into the recorded body and forwards it to the client.
+//
+// Once the body exceeds maxBytes, the copy is dropped and the response is
+// marked uncacheable, so large or streamed responses are not held in memory.
+func (c *cacheRecorder) Write(p []byte) (int, error) {
+	if !c.wroteHeader {
+		c.WriteHeader(http.StatusOK)
+	}
+	if !c.skip {
+		if c.body.Len()+len(p) > c.maxBytes {
+			c.skip = true
+			c.body.Reset()
+		} else {
+			c.body.Write(p)
+		}
+	}
+	return c.ResponseWriter.Write(p)
+}
+
+// flushed records the implicit 200 that flushing sends if nothing was written yet.
+func (c *cacheRecorder) flushed() {
+	c.recordHeader(http.StatusOK)
+}
+
+// hijacked marks the response uncacheable, as it is no longer written through c.
+func (c *cacheRecorder) hijacked() {
+	c.skip = true
+}
+
+// Unwrap returns the underlying ResponseWriter for use with http.ResponseController.
+func (c *cacheRecorder) Unwrap() http.ResponseWriter {
+	return c.ResponseWriter
+}
+
+// cacheable reports whether the recorded response may be stored and served
+// to other clients.
+//
+// Responses that set cookies, are marked private or no-store, or vary by
+// request headers (which the cache key does not include) are shared-unsafe.
+func (c *cacheRecorder) cacheable() bool {
+	if c.skip || !c.wroteHeader || c.statusCode < 200 || c.statusCode > 299 {
+		return false
+	}
+	if len(c.header.Values("Set-Cookie")) > 0 || len(c.header.Values("Vary")) > 0 {
+		return false
+	}
+	for _, value := range c.header.Values("Cache-Control") {
+		for _, directive := range strings.Split(value, ",") {
+			name, _, _ := strings.Cut(strings.TrimSpace(directive), "=")
+			if strings.EqualFold(name, "no-store") || strings.EqualFold(name, "private") {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// Cache returns an HTTP middleware that caches successful GET responses in
+// memory and serves repeated requests without invoking the next handler.
+//
+// Responses are keyed on method and full URL (including the query string).
+// Only 2xx responses are cached. Since cached responses are served to every
+// client, responses that carry a Set-Cookie or Vary header, or whose
+// Cache-Control header contains "private" or "no-store", are never stored.
+// Entries expire after opts.TTL, and the total size of cached bodies is capped
+// at opts.MaxBytes with least recently used entries evicted first. Bodies are
+// captured into pooled buffers while being streamed to the client; capturing
+// stops as soon as a body exceeds opts.MaxBytes. Flushing and hijacking pass
+// through to the underlying ResponseWriter when it supports them.
+//
+// Panics if opts.TTL or opts.MaxBytes is less than or equal to zero.
+//
+// Example:
+//
+//	cache := middleware.Cache(middleware.CacheOptions{
+//		TTL:      30 * time.Second,
+//		MaxBytes: 64 << 20,
+//	})
+//	mux.Handle("/api/products", cache(productsHandler))
+func Cache(opts CacheOptions) func(http.Handler) http.Handler {
+	if opts.TTL <= 0 {
+		panic("middleware.Cache: TTL must be greater than 0")
+	}
+	if opts.MaxBytes <= 0 {
+		panic("middleware.Cache: MaxBytes must be greater than 0")
+	}
+
+	cache := &responseCache{
+		entries:  make(map[string]*list.Element),
+		lru:      list.New(),
+		maxBytes: opts.MaxBytes,
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Method != http.MethodGet {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			key := r.Method + " " + r.URL.String()
+			if entry, ok := cache.get(key, time.Now()); ok {
+				h := w.Header()
+				for k, v := range entry.header {
+					h[k] = v
+				}
+				w.WriteHeader(entry.status)
+				w.Write(entry.body)
+				return
+			}
+
+			buf := bucket.ByteBucket.Get()
+			defer bucket.ByteBucket.Put(buf)
+
+			recorder := &cacheRecorder{ResponseWriter: w, body: buf, maxBytes: opts.MaxBytes}
+			next.ServeHTTP(exposeOptional(recorder), r)
+
+			if recorder.cacheable() {
+				cache.add(&cacheEntry{
+					key:     key,
+					status:  recorder.statusCode,
+					header:  recorder.header,
+					body:    bytes.Clone(buf.Bytes()),
+					expires: time.Now().Add(opts.TTL),
+				})
+			}
+		})
+	}
+}
@@ -0,0 +1,186 @@
+package middleware
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func serve(h http.Handler, method, target string) *httptest.ResponseRecorder {
+	rr := httptest.NewRecorder()
+	h.ServeHTTP(rr, httptest.NewRequest(method, target, nil))
+	return rr
+}
+
+func TestCache_ServesHits(t *testing.T) {
+	var calls int
+	mockHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Header().Set("X-Call", strconv.Itoa(calls))
+		w.WriteHeader(http.StatusAccepted)
+		w.Write([]byte("payload " + r.URL.RawQuery))
+	})
+
+	handlerToTest := Cache(CacheOptions{TTL: time.Minute, MaxBytes: 1024})(mockHandler)
+
+	first := serve(handlerToTest, "GET", "/items?page=1")
+	second := serve(handlerToTest, "GET", "/items?page=1")
+
+	assert.Equal(t, 1, calls, "Second request should be served from cache")
+	assert.Equal(t, http.StatusAccepted, second.Code)
+	assert.Equal(t, "payload page=1", second.Body.String())
+	assert.Equal(t, first.Header().Get("X-Call"), second.Header().Get("X-Call"))
+
+	other := serve(handlerToTest, "GET", "/items?page=2")
+	assert.Equal(t, 2, calls, "A different query string is a different key")
+	assert.Equal(t, "payload page=2", other.Body.String())
+}
+
+func TestCache_Expires(t *testing.T) {
+	var calls int
+	mockHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Write([]byte("ok"))
+	})
+
+	handlerToTest := Cache(CacheOptions{TTL: 20 * time.Millisecond, MaxBytes: 1024})(mockHandler)
+
+	serve(handlerToTest, "GET", "/")
+	time.Sleep(40 * time.Millisecond)
+	serve(handlerToTest, "GET", "/")
+
+	assert.Equal(t, 2, calls)
+}
+
+func TestCache_SkipsUncacheableResponses(t *testing.T) {
+	var calls int
+	mockHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		switch r.URL.Path {
+		case "/nostore":
+			w.Header().Set("Cache-Control", "private, no-store")
+			w.Write([]byte("secret"))
+		case "/missing":
+			http.NotFound(w, r)
+		default:
+			w.Write([]byte("ok"))
+		}
+	})
+
+	handlerToTest := Cache(CacheOptions{TTL: time.Minute, MaxBytes: 1024})(mockHandler)
+
+	serve(handlerToTest, "GET", "/nostore")
+	serve(handlerToTest, "GET", "/nostore")
+	serve(handlerToTest, "GET", "/missing")
+	serve(handlerToTest, "GET", "/missing")
+	serve(handlerToTest, "POST", "/")
+	serve(handlerToTest, "POST", "/")
+
+	assert.Equal(t, 6, calls, "no-store, non-2xx, and non-GET responses must not be cached")
+}
+
+func TestCache_LRUEviction(t *testing.T) {
+	var calls int
+	mockHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Write([]byte(strings.Repeat("x", 40)))
+	})
+
+	handlerToTest := Cache(CacheOptions{TTL: time.Minute, MaxBytes: 100})(mockHandler)
+
+	serve(handlerToTest, "GET", "/a")
+	serve(handlerToTest, "GET", "/b")
+	serve(handlerToTest, "GET", "/a") // hit; /b is now least recently used
+	serve(handlerToTest, "GET", "/c") // evicts /b
+	assert.Equal(t, 3, calls)
+
+	serve(handlerToTest, "GET", "/a")
+	serve(handlerToTest, "GET", "/c")
+	assert.Equal(t, 3, calls, "/a and /c should still be cached")
+
+	serve(handlerToTest, "GET", "/b")
+	assert.Equal(t, 4, calls, "/b should have been evicted")
+}
+
+func TestCache_SkipsOversizedBodies(t *testing.T) {
+	var calls int
+	mockHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Write([]byte(strings.Repeat("x", 200)))
+	})
+
+	handlerToTest := Cache(CacheOptions{TTL: time.Minute, MaxBytes: 100})(mockHandler)
+
+	rr := serve(handlerToTest, "GET", "/big")
+	serve(handlerToTest, "GET", "/big")
+
+	assert.Equal(t, 200, rr.Body.Len(), "Oversized responses are still delivered")
+	assert.Equal(t, 2, calls)
+}
+
+func TestCache_PanicsOnInvalidOptions(t *testing.T) {
+	assert.Panics(t, func() { Cache(CacheOptions{MaxBytes: 1}) })
+	assert.Panics(t, func() { Cache(CacheOptions{TTL: time.Second}) })
+}
+
+func TestCache_SkipsSharedUnsafeResponses(t *testing.T) {
+	cases := map[string]func(h http.Header){
+		"Set-Cookie":      func(h http.Header) { h.Set("Set-Cookie", "session=alice") },
+		"private":         func(h http.Header) { h.Set("Cache-Control", "private, max-age=60") },
+		"no-store":        func(h http.Header) { h.Set("Cache-Control", "no-store") },
+		"no-store (case)": func(h http.Header) { h.Add("Cache-Control", "max-age=60"); h.Add("Cache-Control", "No-Store") },
+		"Vary":            func(h http.Header) { h.Set("Vary", "Accept-Encoding") },
+	}
+
+	for name, setHeader := range cases {
+		t.Run(name, func(t *testing.T) {
+			var calls int
+			mockHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				calls++
+				setHeader(w.Header())
+				w.Write([]byte("ok"))
+			})
+
+			handlerToTest := Cache(CacheOptions{TTL: time.Minute, MaxBytes: 1024})(mockHandler)
+
+			serve(handlerToTest, "GET", "/")
+			second := serve(handlerToTest, "GET", "/")
+
+			assert.Equal(t, 2, calls, "The response must not be cached")
+			if name == "Set-Cookie" {
+				assert.Equal(t, "session=alice", second.Header().Get("Set-Cookie"), "The cookie should come from the handler itself")
+			}
+		})
+	}
+}
+
+func TestCache_StopsCapturingOversizedBodies(t *testing.T) {
+	rr := httptest.NewRecorder()
+	recorder := &cacheRecorder{ResponseWriter: rr, body: new(bytes.Buffer), maxBytes: 100}
+
+	for i := 0; i < 10; i++ {
+		recorder.Write([]byte(strings.Repeat("x", 50)))
+	}
+
+	assert.Equal(t, 500, rr.Body.Len(), "The whole body should still be delivered")
+	assert.Equal(t, 0, recorder.body.Len(), "The copy should be dropped once it passes MaxBytes")
+	assert.False(t, recorder.cacheable())
+}
+
+func TestCache_PassesFlushThrough(t *testing.T) {
+	mockHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("data: 1\n\n"))
+		w.(http.Flusher).Flush()
+	})
+
+	handlerToTest := Cache(CacheOptions{TTL: time.Minute, MaxBytes: 1024})(mockHandler)
+
+	rr := serve(handlerToTest, "GET", "/events")
+	assert.True(t, rr.Flushed, "Flush should reach the underlying writer")
+}
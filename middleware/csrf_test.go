@@ -0,0 +1,108 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func csrfEcho() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(CSRFToken(r)))
+	})
+}
+
+func TestCSRF_SafeMethodIssuesCookie(t *testing.T) {
+	handlerToTest := CSRF(CSRFOptions{})(csrfEcho())
+
+	rr := serve(handlerToTest, "GET", "/form")
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	cookies := rr.Result().Cookies()
+	if assert.Len(t, cookies, 1) {
+		assert.Equal(t, "csrf_token", cookies[0].Name)
+		assert.Equal(t, http.SameSiteLaxMode, cookies[0].SameSite)
+		assert.False(t, cookies[0].Secure)
+		assert.NotEmpty(t, cookies[0].Value)
+		assert.Equal(t, cookies[0].Value, rr.Body.String(), "Handler should see the issued token")
+	}
+}
+
+func TestCSRF_ReusesExistingCookie(t *testing.T) {
+	handlerToTest := CSRF(CSRFOptions{})(csrfEcho())
+
+	req := httptest.NewRequest("GET", "/", nil)
+	req.AddCookie(&http.Cookie{Name: "csrf_token", Value: "existing"})
+	rr := httptest.NewRecorder()
+	handlerToTest.ServeHTTP(rr, req)
+
+	assert.Empty(t, rr.Result().Cookies())
+	assert.Equal(t, "existing", rr.Body.String())
+}
+
+func TestCSRF_UnsafeMethodValidation(t *testing.T) {
+	handlerToTest := CSRF(CSRFOptions{})(csrfEcho())
+
+	tests := []struct {
+		name   string
+		cookie string
+		header string
+		form   string
+		want   int
+	}{
+		{"header match", "tok", "tok", "", http.StatusOK},
+		{"form match", "tok", "", "tok", http.StatusOK},
+		{"mismatch", "tok", "other", "", http.StatusForbidden},
+		{"missing token", "tok", "", "", http.StatusForbidden},
+		{"missing cookie", "", "tok", "", http.StatusForbidden},
+	}
+	for _, tt := range tests {
+		var body *strings.Reader
+		if tt.form != "" {
+			body = strings.NewReader("csrf_token=" + tt.form)
+		} else {
+			body = strings.NewReader("")
+		}
+		req := httptest.NewRequest("POST", "/submit", body)
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		if tt.cookie != "" {
+			req.AddCookie(&http.Cookie{Name: "csrf_token", Value: tt.cookie})
+		}
+		if tt.header != "" {
+			req.Header.Set("X-CSRF-Token", tt.header)
+		}
+		rr := httptest.NewRecorder()
+
+		handlerToTest.ServeHTTP(rr, req)
+
+		assert.Equal(t, tt.want, rr.Code, tt.name)
+	}
+}
+
+func TestCSRF_CustomOptions(t *testing.T) {
+	handlerToTest := CSRF(CSRFOptions{
+		CookieName: "xsrf",
+		HeaderName: "X-XSRF-Token",
+		SameSite:   http.SameSiteStrictMode,
+		Secure:     true,
+	})(csrfEcho())
+
+	rr := serve(handlerToTest, "GET", "/")
+	cookies := rr.Result().Cookies()
+	if assert.Len(t, cookies, 1) {
+		assert.Equal(t, "xsrf", cookies[0].Name)
+		assert.Equal(t, http.SameSiteStrictMode, cookies[0].SameSite)
+		assert.True(t, cookies[0].Secure)
+	}
+
+	req := httptest.NewRequest("DELETE", "/item/1", nil)
+	req.AddCookie(&http.Cookie{Name: "xsrf", Value: "tok"})
+	req.Header.Set("X-XSRF-Token", "tok")
+	rr = httptest.NewRecorder()
+	handlerToTest.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+}
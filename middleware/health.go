@@ -0,0 +1,105 @@
+package middleware
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+
+	"github.com/iameggi/cassie/helpers"
+	"github.com/iameggi/cassie/weave"
+)
+
+// healthResponse is the JSON body written by Health.
+type healthResponse struct {
+	Status string            `json:"status"`
+	Checks map[string]string `json:"checks"`
+}
+
+// Health returns an http.Handler for liveness and readiness probes.
+//
+// On each request it runs every registered check concurrently (via
+// weave.Sail) with the request's context, and responds with the status of
+// each check. A check passes by returning nil; its error message (or panic
+// value) is reported otherwise. If every check passes the response is
+// 200 OK, otherwise 503 Service Unavailable:
+//
+//	{"status":"ok","checks":{"db":"ok","cache":"ok"}}
+//	{"status":"unavailable","checks":{"db":"ok","cache":"dial tcp: connection refused"}}
+//
+// Checks should honor ctx, which is canceled when the client disconnects;
+// use a timeout middleware or context.WithTimeout inside a check to bound it.
+// Checks skipped because of the cancellation are reported as failed. A failure
+// to write the response is recorded with SetLogError.
+//
+// Example:
+//
+//	mux.Handle("/healthz", middleware.Health(nil))
+//	mux.Handle("/readyz", middleware.Health(map[string]func(context.Context) error{
+//		"db": db.PingContext,
+//	}))
+func Health(checks map[string]func(ctx context.Context) error) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var mu sync.Mutex
+		results := make(map[string]string, len(checks))
+
+		tasks := make([]weave.Task, 0, len(checks))
+		for name, check := range checks {
+			tasks = append(tasks, func(ctx context.Context) error {
+				status := runHealthCheck(ctx, check)
+				mu.Lock()
+				results[name] = status
+				mu.Unlock()
+				// Never fail the task, so Sail waits for every check to report.
+				return nil
+			})
+		}
+
+		ctxErr := weave.Sail(r.Context(), tasks...)
+		if ctxErr == nil {
+			// Sail may report success even though cancellation made it skip
+			// checks, so fall back to the request's own context error.
+			ctxErr = r.Context().Err()
+		}
+
+		mu.Lock()
+		resp := healthResponse{Status: "ok", Checks: make(map[string]string, len(checks))}
+		for name := range checks {
+			status, ok := results[name]
+			if !ok {
+				status = "canceled"
+				if ctxErr != nil {
+					status = ctxErr.Error()
+				}
+			}
+			if status != "ok" {
+				resp.Status = "unavailable"
+			}
+			resp.Checks[name] = status
+		}
+		mu.Unlock()
+
+		statusCode := http.StatusOK
+		if resp.Status != "ok" {
+			statusCode = http.StatusServiceUnavailable
+		}
+		if err := helpers.SendJSON(w, statusCode, resp); err != nil {
+			SetLogError(r, err)
+		}
+	})
+}
+
+// runHealthCheck runs check and describes its outcome, converting a panic
+// into a failure.
+func runHealthCheck(ctx context.Context, check func(ctx context.Context) error) (status string) {
+	defer func() {
+		if r := recover(); r != nil {
+			status = fmt.Sprintf("panic: %v", r)
+		}
+	}()
+
+	if err := check(ctx); err != nil {
+		return err.Error()
+	}
+	return "ok"
+}
@@ -0,0 +1,106 @@
+package middleware
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func decodeHealth(t *testing.T, rr *httptest.ResponseRecorder) healthResponse {
+	var resp healthResponse
+	assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+	return resp
+}
+
+func TestHealth_AllPass(t *testing.T) {
+	handlerToTest := Health(map[string]func(context.Context) error{
+		"db":    func(ctx context.Context) error { return nil },
+		"cache": func(ctx context.Context) error { return nil },
+	})
+
+	rr := serve(handlerToTest, "GET", "/healthz")
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, "application/json; charset=utf-8", rr.Header().Get("Content-Type"))
+	assert.Equal(t, healthResponse{
+		Status: "ok",
+		Checks: map[string]string{"db": "ok", "cache": "ok"},
+	}, decodeHealth(t, rr))
+}
+
+func TestHealth_Failures(t *testing.T) {
+	handlerToTest := Health(map[string]func(context.Context) error{
+		"db":    func(ctx context.Context) error { return nil },
+		"cache": func(ctx context.Context) error { return errors.New("connection refused") },
+		"queue": func(ctx context.Context) error { panic("broken") },
+	})
+
+	rr := serve(handlerToTest, "GET", "/readyz")
+
+	assert.Equal(t, http.StatusServiceUnavailable, rr.Code)
+	assert.Equal(t, healthResponse{
+		Status: "unavailable",
+		Checks: map[string]string{"db": "ok", "cache": "connection refused", "queue": "panic: broken"},
+	}, decodeHealth(t, rr))
+}
+
+func TestHealth_RunsChecksConcurrently(t *testing.T) {
+	slow := func(ctx context.Context) error {
+		time.Sleep(50 * time.Millisecond)
+		return nil
+	}
+	handlerToTest := Health(map[string]func(context.Context) error{"a": slow, "b": slow, "c": slow})
+
+	start := time.Now()
+	rr := serve(handlerToTest, "GET", "/healthz")
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Less(t, time.Since(start), 140*time.Millisecond, "Checks should run concurrently")
+}
+
+func TestHealth_NoChecks(t *testing.T) {
+	rr := serve(Health(nil), "GET", "/healthz")
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, "ok", decodeHealth(t, rr).Status)
+}
+
+func TestHealth_CanceledRequest(t *testing.T) {
+	checks := map[string]func(context.Context) error{
+		"db":    func(ctx context.Context) error { return nil },
+		"cache": func(ctx context.Context) error { return nil },
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	rr := httptest.NewRecorder()
+	Health(checks).ServeHTTP(rr, httptest.NewRequest("GET", "/", nil).WithContext(ctx))
+
+	assert.Equal(t, http.StatusServiceUnavailable, rr.Code)
+	resp := decodeHealth(t, rr)
+	assert.Equal(t, "context canceled", resp.Checks["db"], "Skipped checks should report the cancellation")
+}
+
+func TestHealth_CanceledWhileStarting(t *testing.T) {
+	for i := 0; i < 100; i++ {
+		ctx, cancel := context.WithCancel(context.Background())
+		checks := map[string]func(context.Context) error{
+			"first": func(context.Context) error { cancel(); return nil },
+		}
+		for j := 0; j < 20; j++ {
+			checks["check"+string(rune('a'+j))] = func(context.Context) error { return nil }
+		}
+
+		rr := httptest.NewRecorder()
+		assert.NotPanics(t, func() {
+			Health(checks).ServeHTTP(rr, httptest.NewRequest("GET", "/", nil).WithContext(ctx))
+		})
+		cancel()
+	}
+}
@@ -0,0 +1,56 @@
+package middleware
+
+import (
+	"net/http"
+	"time"
+)
+
+// LastModified returns an HTTP middleware that implements conditional GET
+// using the Last-Modified and If-Modified-Since headers.
+//
+// For GET and HEAD requests, fn resolves the modification time of the
+// requested resource. The middleware sets the Last-Modified header from it
+// and, when the request's If-Modified-Since is at or after that time, replies
+// with 304 Not Modified without calling the next handler. Times are compared
+// at one-second precision, the resolution of HTTP dates.
+//
+// If fn returns the zero time, or the request carries If-None-Match (which
+// takes precedence, see helpers.SendJSONWithETag), the request is passed
+// through unchanged. Other methods are never affected.
+//
+// Example:
+//
+//	handler := middleware.LastModified(func(r *http.Request) time.Time {
+//		return catalog.UpdatedAt()
+//	})(catalogHandler)
+func LastModified(fn func(*http.Request) time.Time) func(http.Handler) http.Handler {
+	if fn == nil {
+		panic("middleware.LastModified: fn must not be nil")
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Method != http.MethodGet && r.Method != http.MethodHead {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			modified := fn(r)
+			if modified.IsZero() {
+				next.ServeHTTP(w, r)
+				return
+			}
+			modified = modified.Truncate(time.Second)
+			w.Header().Set("Last-Modified", modified.UTC().Format(http.TimeFormat))
+
+			if r.Header.Get("If-None-Match") == "" {
+				if since, err := http.ParseTime(r.Header.Get("If-Modified-Since")); err == nil && !modified.After(since) {
+					w.WriteHeader(http.StatusNotModified)
+					return
+				}
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,88 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var lastModifiedTime = time.Date(2024, 5, 1, 12, 0, 0, 500_000_000, time.UTC)
+
+func newLastModifiedHandler(calls *int) http.Handler {
+	mockHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*calls++
+		w.Write([]byte("body"))
+	})
+	return LastModified(func(r *http.Request) time.Time { return lastModifiedTime })(mockHandler)
+}
+
+func TestLastModified_SetsHeader(t *testing.T) {
+	var calls int
+	handlerToTest := newLastModifiedHandler(&calls)
+
+	req := httptest.NewRequest("GET", "/", nil)
+	rr := httptest.NewRecorder()
+
+	handlerToTest.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, "Wed, 01 May 2024 12:00:00 GMT", rr.Header().Get("Last-Modified"))
+	assert.Equal(t, "body", rr.Body.String())
+	assert.Equal(t, 1, calls)
+}
+
+func TestLastModified_NotModified(t *testing.T) {
+	for _, since := range []string{"Wed, 01 May 2024 12:00:00 GMT", "Thu, 02 May 2024 00:00:00 GMT"} {
+		var calls int
+		handlerToTest := newLastModifiedHandler(&calls)
+
+		req := httptest.NewRequest("GET", "/", nil)
+		req.Header.Set("If-Modified-Since", since)
+		rr := httptest.NewRecorder()
+
+		handlerToTest.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusNotModified, rr.Code, "If-Modified-Since: %s", since)
+		assert.Empty(t, rr.Body.String())
+		assert.Equal(t, 0, calls, "Handler should not run on 304")
+	}
+}
+
+func TestLastModified_Modified(t *testing.T) {
+	var calls int
+	handlerToTest := newLastModifiedHandler(&calls)
+
+	req := httptest.NewRequest("GET", "/", nil)
+	req.Header.Set("If-Modified-Since", "Wed, 01 May 2024 11:59:59 GMT")
+	rr := httptest.NewRecorder()
+
+	handlerToTest.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, 1, calls)
+}
+
+func TestLastModified_IgnoresOtherMethodsAndETags(t *testing.T) {
+	var calls int
+	handlerToTest := newLastModifiedHandler(&calls)
+
+	req := httptest.NewRequest("POST", "/", nil)
+	req.Header.Set("If-Modified-Since", "Thu, 02 May 2024 00:00:00 GMT")
+	rr := httptest.NewRecorder()
+	handlerToTest.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Empty(t, rr.Header().Get("Last-Modified"))
+
+	req = httptest.NewRequest("GET", "/", nil)
+	req.Header.Set("If-Modified-Since", "Thu, 02 May 2024 00:00:00 GMT")
+	req.Header.Set("If-None-Match", `"abc"`)
+	rr = httptest.NewRecorder()
+	handlerToTest.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code, "If-None-Match takes precedence over If-Modified-Since")
+	assert.Equal(t, 2, calls)
+}
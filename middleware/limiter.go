@@ -1,44 +1,319 @@
 package middleware
 
 import (
+	"container/list"
+	"context"
+	"math"
 	"net/http"
+	"sync"
+	"time"
 )
 
 // Limiter is an HTTP middleware that limits the number of concurrent requests
-// being processed at any given time.
+// being processed at any given time, and optionally their rate.
+//
+// The limit can be changed at runtime with SetMax.
 type Limiter struct {
-	// semaphore acts as a concurrency control mechanism.
-	// Each slot represents one active request being processed.
-	semaphore chan struct{}
+	mu    sync.Mutex
+	max   int // Maximum number of requests allowed in flight.
+	inUse int // Number of requests currently in flight.
+
+	// Blocked requests wait here (as *limiterTicket) in arrival order. A
+	// freed slot wakes only the front one; with WithFairQueue, the slot is
+	// also handed over to it, so no later request can take it first.
+	queue *list.List
+	fair  bool
+
+	reject bool // Respond 503 instead of blocking when all slots are taken.
+
+	// Token bucket enforcing WithRateLimit; rate 0 disables it.
+	rate   float64   // Tokens added per second.
+	burst  float64   // Maximum number of tokens.
+	tokens float64   // Tokens currently available.
+	last   time.Time // When tokens was last refilled.
+}
+
+// limiterTicket is a blocked request's place in the queue. ready is closed
+// once the request has been woken (or, with WithFairQueue, handed a slot).
+type limiterTicket struct {
+	ready chan struct{}
+}
+
+// statusClientClosedRequest is the non-standard status (popularized by nginx)
+// recorded for requests abandoned because the client disconnected.
+const statusClientClosedRequest = 499
+
+// LimiterOption configures optional Limiter behavior.
+type LimiterOption func(*Limiter)
+
+// WithReject makes the Limiter respond with 503 Service Unavailable when all
+// slots are taken, instead of blocking the request until one is released.
+func WithReject() LimiterOption {
+	return func(l *Limiter) {
+		l.reject = true
+	}
+}
+
+// WithFairQueue makes blocked requests acquire slots in the order they
+// arrived, so that under contention a late request can never overtake an
+// earlier one. By default, a woken request competes for the freed slot with
+// newly arriving ones and may lose it, which is cheaper but can starve
+// unlucky requests.
+//
+// Requests whose client disconnects still leave the queue, and WithReject and
+// WithRateLimit apply as usual.
+func WithFairQueue() LimiterOption {
+	return func(l *Limiter) {
+		l.fair = true
+	}
+}
+
+// WithRateLimit additionally caps the rate of admitted requests at rps per
+// second. Requests over the rate are rejected with 429 Too Many Requests
+// before they take a concurrency slot.
+//
+// The rate is enforced with a token bucket that allows bursts of up to one
+// second's worth of requests (at least one).
+//
+// Panics if rps is not greater than zero.
+func WithRateLimit(rps float64) LimiterOption {
+	if !(rps > 0) || math.IsInf(rps, 1) {
+		panic("middleware.WithRateLimit: rps must be a positive finite number")
+	}
+
+	return func(l *Limiter) {
+		l.rate = rps
+		l.burst = math.Max(1, rps)
+		l.tokens = l.burst
+	}
 }
 
 // NewLimiter creates a new Limiter instance with the specified maximum concurrency.
 //
+// Optional behavior can be enabled by passing LimiterOption values.
+//
 // Panics if maxConcurrency is less than or equal to zero.
-func NewLimiter(maxConcurrency int) *Limiter {
+func NewLimiter(maxConcurrency int, opts ...LimiterOption) *Limiter {
 	if maxConcurrency <= 0 {
 		panic("middleware.NewLimiter: maxConcurrency must be greater than 0")
 	}
 
-	return &Limiter{
-		// The buffered channel acts as a semaphore with a capacity equal
-		// to the allowed number of concurrent requests.
-		semaphore: make(chan struct{}, maxConcurrency),
+	l := &Limiter{
+		max:   maxConcurrency,
+		queue: list.New(),
+	}
+	for _, opt := range opts {
+		opt(l)
+	}
+	return l
+}
+
+// NewThrottle creates a Limiter that bounds both the number of in-flight
+// requests and the request rate, combining NewLimiter and WithRateLimit.
+//
+// Requests over rps are rejected with 429 Too Many Requests. When all
+// maxConcurrency slots are taken, requests block, or are rejected with
+// 503 Service Unavailable if WithReject is passed.
+//
+// Panics if maxConcurrency is less than or equal to zero or rps is not
+// greater than zero.
+func NewThrottle(maxConcurrency int, rps float64, opts ...LimiterOption) *Limiter {
+	return NewLimiter(maxConcurrency, append([]LimiterOption{WithRateLimit(rps)}, opts...)...)
+}
+
+// ConcurrencyLimit returns an HTTP middleware that limits the number of
+// concurrent requests, for use in chains alongside Logger and Recovery. It is
+// shorthand for NewLimiter(maxConcurrency, opts...).Wrap; use NewLimiter
+// directly to keep a handle for SetMax.
+//
+// Example:
+//
+//	handler := middleware.ConcurrencyLimit(100, middleware.WithReject())(router)
+//
+// Panics if maxConcurrency is less than or equal to zero.
+func ConcurrencyLimit(maxConcurrency int, opts ...LimiterOption) func(http.Handler) http.Handler {
+	return NewLimiter(maxConcurrency, opts...).Wrap
+}
+
+// SetMax changes the concurrency limit at runtime. It is safe to call
+// concurrently with requests flowing through Wrap.
+//
+// Growing the limit immediately admits waiting requests into the new slots.
+// Shrinking it never interrupts requests already in flight: if more requests
+// are running than the new limit allows, no new request is admitted until
+// enough of them have finished. SetMax itself does not block.
+//
+// Panics if n is less than or equal to zero.
+func (l *Limiter) SetMax(n int) {
+	if n <= 0 {
+		panic("middleware.Limiter.SetMax: n must be greater than 0")
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	grew := n > l.max
+	l.max = n
+	if grew {
+		l.notify()
+	}
+}
+
+// allow reports whether the request rate permits another request, consuming
+// a token if so. It always returns true when no rate limit is configured.
+func (l *Limiter) allow() bool {
+	if l.rate == 0 {
+		return true
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	if !l.last.IsZero() {
+		l.tokens = math.Min(l.burst, l.tokens+now.Sub(l.last).Seconds()*l.rate)
+	}
+	l.last = now
+
+	if l.tokens < 1 {
+		return false
+	}
+	l.tokens--
+	return true
+}
+
+// tryAcquire takes a slot if one is available, without blocking.
+func (l *Limiter) tryAcquire() bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.inUse < l.max {
+		l.inUse++
+		return true
+	}
+	return false
+}
+
+// acquire blocks until a slot is available and takes it, or until ctx is
+// done, in which case no slot is taken and ctx.Err() is returned.
+func (l *Limiter) acquire(ctx context.Context) error {
+	if l.fair {
+		return l.acquireFair(ctx)
+	}
+
+	for {
+		l.mu.Lock()
+		if l.inUse < l.max {
+			l.inUse++
+			l.mu.Unlock()
+			return nil
+		}
+		ticket := &limiterTicket{ready: make(chan struct{})}
+		elem := l.queue.PushBack(ticket)
+		l.mu.Unlock()
+
+		select {
+		case <-ticket.ready:
+			// Woken by a freed slot; compete for it.
+		case <-ctx.Done():
+			l.mu.Lock()
+			defer l.mu.Unlock()
+			select {
+			case <-ticket.ready:
+				// Woken just as the client gave up; wake someone else.
+				l.notify()
+			default:
+				l.queue.Remove(elem)
+			}
+			return ctx.Err()
+		}
+	}
+}
+
+// acquireFair is acquire for WithFairQueue: the request takes a ticket at
+// the back of the queue and waits for release to hand it a slot.
+func (l *Limiter) acquireFair(ctx context.Context) error {
+	l.mu.Lock()
+	if l.inUse < l.max && l.queue.Len() == 0 {
+		l.inUse++
+		l.mu.Unlock()
+		return nil
+	}
+	ticket := &limiterTicket{ready: make(chan struct{})}
+	elem := l.queue.PushBack(ticket)
+	l.mu.Unlock()
+
+	select {
+	case <-ticket.ready:
+		return nil
+	case <-ctx.Done():
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	select {
+	case <-ticket.ready:
+		// A slot was handed over just as the client gave up; pass it on.
+		l.inUse--
+		l.notify()
+	default:
+		l.queue.Remove(elem)
+	}
+	return ctx.Err()
+}
+
+// release returns a slot taken by acquire.
+func (l *Limiter) release() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.inUse--
+	l.notify()
+}
+
+// notify wakes as many requests waiting in acquire as there are free slots,
+// in arrival order. With WithFairQueue, the slots are taken on their behalf.
+// It does nothing when no request is waiting. The caller must hold l.mu.
+func (l *Limiter) notify() {
+	for free := l.max - l.inUse; free > 0 && l.queue.Len() > 0; free-- {
+		ticket := l.queue.Remove(l.queue.Front()).(*limiterTicket)
+		if l.fair {
+			l.inUse++
+		}
+		close(ticket.ready)
 	}
 }
 
 // Wrap returns a new http.Handler that enforces the concurrency limit.
 //
-// When all slots are full, new requests will block until a slot is released.
+// When all slots are full, new requests will block until a slot is released,
+// or are rejected with 503 when WithReject is set. A request whose client
+// disconnects while it is waiting is abandoned without taking a slot or
+// reaching next, and is recorded with the non-standard status 499. Requests exceeding the
+// WithRateLimit rate, if any, are rejected with 429.
 func (l *Limiter) Wrap(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		// Acquire a slot — this will block if the semaphore is full.
-		l.semaphore <- struct{}{}
+		if !l.allow() {
+			http.Error(w, http.StatusText(http.StatusTooManyRequests), http.StatusTooManyRequests)
+			return
+		}
+
+		if l.reject {
+			if !l.tryAcquire() {
+				http.Error(w, http.StatusText(http.StatusServiceUnavailable), http.StatusServiceUnavailable)
+				return
+			}
+		} else {
+			// Acquire a slot — this will block if all slots are taken, unless
+			// the client gives up first, in which case the request is abandoned.
+			if err := l.acquire(r.Context()); err != nil {
+				w.WriteHeader(statusClientClosedRequest)
+				return
+			}
+		}
 
 		// Ensure the slot is released even if the handler panics.
-		defer func() {
-			<-l.semaphore
-		}()
+		defer l.release()
 
 		// Continue to the next handler in the chain.
 		next.ServeHTTP(w, r)
@@ -1,8 +1,10 @@
 package middleware
 
 import (
+	"context"
 	"net/http"
 	"net/http/httptest"
+	"strconv"
 	"sync"
 	"testing"
 	"time"
@@ -54,3 +56,349 @@ func TestLimiter(t *testing.T) {
 
 	wg.Wait()
 }
+
+func TestLimiter_SetMax(t *testing.T) {
+	limiter := NewLimiter(2)
+
+	handlerRunning := make(chan struct{}, 8)
+	handlerFinish := make(chan struct{})
+
+	mockHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		handlerRunning <- struct{}{}
+		<-handlerFinish
+	})
+
+	handlerToTest := limiter.Wrap(mockHandler)
+	const totalRequests = 5
+	var wg sync.WaitGroup
+	wg.Add(totalRequests)
+
+	for i := 0; i < totalRequests; i++ {
+		go func() {
+			defer wg.Done()
+			req := httptest.NewRequest("GET", "/", nil)
+			rr := httptest.NewRecorder()
+			handlerToTest.ServeHTTP(rr, req)
+		}()
+	}
+
+	time.Sleep(50 * time.Millisecond)
+	assert.Equal(t, 2, len(handlerRunning), "Initial limit should admit 2 requests")
+
+	// Growing admits waiting requests immediately.
+	limiter.SetMax(4)
+	time.Sleep(50 * time.Millisecond)
+	assert.Equal(t, 4, len(handlerRunning), "Raised limit should admit 2 more requests")
+
+	// Shrinking below current usage must not deadlock; the fifth request
+	// waits until in-flight requests drop below the new limit.
+	limiter.SetMax(1)
+	handlerFinish <- struct{}{}
+	handlerFinish <- struct{}{}
+	handlerFinish <- struct{}{}
+	time.Sleep(50 * time.Millisecond)
+	assert.Equal(t, 4, len(handlerRunning), "No request should start while 1 is still in flight")
+
+	handlerFinish <- struct{}{}
+	time.Sleep(50 * time.Millisecond)
+	assert.Equal(t, totalRequests, len(handlerRunning), "Last request should start once usage drops below the limit")
+
+	handlerFinish <- struct{}{}
+	wg.Wait()
+}
+
+func TestLimiter_SetMaxPanicsOnInvalidValue(t *testing.T) {
+	assert.Panics(t, func() { NewLimiter(1).SetMax(0) })
+}
+
+func TestLimiter_WithReject(t *testing.T) {
+	limiter := NewLimiter(1, WithReject())
+
+	handlerRunning := make(chan struct{})
+	handlerFinish := make(chan struct{})
+
+	mockHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		close(handlerRunning)
+		<-handlerFinish
+	})
+
+	handlerToTest := limiter.Wrap(mockHandler)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		handlerToTest.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/", nil))
+	}()
+	<-handlerRunning
+
+	rr := httptest.NewRecorder()
+	handlerToTest.ServeHTTP(rr, httptest.NewRequest("GET", "/", nil))
+	assert.Equal(t, http.StatusServiceUnavailable, rr.Code, "Saturated limiter should reject with 503")
+
+	close(handlerFinish)
+	<-done
+}
+
+func TestThrottle_RateLimit(t *testing.T) {
+	limiter := NewThrottle(10, 2)
+
+	mockHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	handlerToTest := limiter.Wrap(mockHandler)
+
+	codes := make([]int, 3)
+	for i := range codes {
+		rr := httptest.NewRecorder()
+		handlerToTest.ServeHTTP(rr, httptest.NewRequest("GET", "/", nil))
+		codes[i] = rr.Code
+	}
+	assert.Equal(t, []int{http.StatusOK, http.StatusOK, http.StatusTooManyRequests}, codes, "Burst should be one second's worth of requests")
+
+	time.Sleep(600 * time.Millisecond)
+
+	rr := httptest.NewRecorder()
+	handlerToTest.ServeHTTP(rr, httptest.NewRequest("GET", "/", nil))
+	assert.Equal(t, http.StatusOK, rr.Code, "Tokens should refill over time")
+}
+
+func TestWithRateLimit_PanicsOnInvalidRate(t *testing.T) {
+	assert.Panics(t, func() { WithRateLimit(0) })
+	assert.Panics(t, func() { NewThrottle(1, -1) })
+}
+
+func TestLimiter_ClientDisconnectWhileQueued(t *testing.T) {
+	limiter := NewLimiter(1)
+
+	handlerRunning := make(chan struct{}, 2)
+	handlerFinish := make(chan struct{})
+
+	mockHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		handlerRunning <- struct{}{}
+		<-handlerFinish
+	})
+
+	handlerToTest := limiter.Wrap(mockHandler)
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		handlerToTest.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/", nil))
+	}()
+	<-handlerRunning
+
+	ctx, cancel := context.WithCancel(context.Background())
+	queued := httptest.NewRecorder()
+	abandoned := make(chan struct{})
+	go func() {
+		defer close(abandoned)
+		handlerToTest.ServeHTTP(queued, httptest.NewRequest("GET", "/", nil).WithContext(ctx))
+	}()
+
+	time.Sleep(20 * time.Millisecond)
+	cancel()
+	<-abandoned
+
+	assert.Equal(t, 499, queued.Code, "Abandoned requests should be recorded as 499")
+
+	close(handlerFinish)
+	wg.Wait()
+	assert.Equal(t, 0, len(handlerRunning), "The abandoned request must not reach the handler")
+
+	// The abandoned request must not have consumed the slot.
+	rr := httptest.NewRecorder()
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		handlerToTest.ServeHTTP(rr, httptest.NewRequest("GET", "/", nil))
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Slot was leaked by the abandoned request")
+	}
+}
+
+func TestLimiter_WithFairQueue_Order(t *testing.T) {
+	limiter := NewLimiter(1, WithFairQueue())
+
+	var mu sync.Mutex
+	var order []int
+	holding := make(chan struct{})
+	release := make(chan struct{})
+
+	handlerToTest := limiter.Wrap(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/hold" {
+			close(holding)
+			<-release
+			return
+		}
+		id, _ := strconv.Atoi(r.URL.Query().Get("id"))
+		mu.Lock()
+		order = append(order, id)
+		mu.Unlock()
+	}))
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		handlerToTest.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/hold", nil))
+	}()
+	<-holding
+
+	// Queue the requests one at a time so their arrival order is known.
+	const waiting = 10
+	for i := 0; i < waiting; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			handlerToTest.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/?id="+strconv.Itoa(i), nil))
+		}(i)
+		assert.Eventually(t, func() bool {
+			limiter.mu.Lock()
+			defer limiter.mu.Unlock()
+			return limiter.queue.Len() == i+1
+		}, time.Second, time.Millisecond)
+	}
+
+	close(release)
+	wg.Wait()
+
+	expected := make([]int, waiting)
+	for i := range expected {
+		expected[i] = i
+	}
+	assert.Equal(t, expected, order, "Queued requests should be served in arrival order")
+}
+
+func TestLimiter_WithFairQueue_ClientDisconnect(t *testing.T) {
+	limiter := NewLimiter(1, WithFairQueue())
+
+	holding := make(chan struct{})
+	release := make(chan struct{})
+	var served sync.Map
+
+	handlerToTest := limiter.Wrap(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/hold" {
+			close(holding)
+			<-release
+			return
+		}
+		served.Store(r.URL.Path, true)
+	}))
+
+	go handlerToTest.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/hold", nil))
+	<-holding
+
+	queueLen := func() int {
+		limiter.mu.Lock()
+		defer limiter.mu.Unlock()
+		return limiter.queue.Len()
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	abandoned := httptest.NewRecorder()
+	abandonedDone := make(chan struct{})
+	go func() {
+		defer close(abandonedDone)
+		handlerToTest.ServeHTTP(abandoned, httptest.NewRequest("GET", "/abandoned", nil).WithContext(ctx))
+	}()
+	assert.Eventually(t, func() bool { return queueLen() == 1 }, time.Second, time.Millisecond)
+
+	nextDone := make(chan struct{})
+	go func() {
+		defer close(nextDone)
+		handlerToTest.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/next", nil))
+	}()
+	assert.Eventually(t, func() bool { return queueLen() == 2 }, time.Second, time.Millisecond)
+
+	cancel()
+	<-abandonedDone
+	assert.Equal(t, 499, abandoned.Code, "Abandoned requests should be recorded as 499")
+	assert.Equal(t, 1, queueLen(), "The abandoned request should leave the queue")
+
+	close(release)
+	select {
+	case <-nextDone:
+	case <-time.After(time.Second):
+		t.Fatal("The next queued request was not served")
+	}
+	_, ok := served.Load("/abandoned")
+	assert.False(t, ok, "The abandoned request must not reach the handler")
+}
+
+func TestConcurrencyLimit(t *testing.T) {
+	started := make(chan struct{})
+	finish := make(chan struct{})
+	handler := ConcurrencyLimit(1, WithReject())(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		close(started)
+		<-finish
+	}))
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/", nil))
+	}()
+	<-started
+
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, httptest.NewRequest("GET", "/", nil))
+	assert.Equal(t, http.StatusServiceUnavailable, rr.Code, "The second request should be rejected while the slot is taken")
+
+	close(finish)
+	<-done
+}
+
+func TestConcurrencyLimit_PanicsOnInvalidValue(t *testing.T) {
+	assert.Panics(t, func() { ConcurrencyLimit(0) })
+}
+
+func TestLimiter_UncontendedDoesNotAllocate(t *testing.T) {
+	limiter := NewLimiter(1)
+	ctx := context.Background()
+
+	allocs := testing.AllocsPerRun(100, func() {
+		if err := limiter.acquire(ctx); err != nil {
+			t.Fatal(err)
+		}
+		limiter.release()
+	})
+	assert.Equal(t, 0.0, allocs, "Acquire and release without waiters should not allocate")
+}
+
+func TestLimiter_ReleaseWakesOneWaiter(t *testing.T) {
+	limiter := NewLimiter(1)
+	ctx := context.Background()
+	assert.NoError(t, limiter.acquire(ctx))
+
+	queueLen := func() int {
+		limiter.mu.Lock()
+		defer limiter.mu.Unlock()
+		return limiter.queue.Len()
+	}
+
+	const waiting = 3
+	acquired := make(chan struct{}, waiting)
+	for i := 0; i < waiting; i++ {
+		go func() {
+			if limiter.acquire(ctx) == nil {
+				acquired <- struct{}{}
+			}
+		}()
+	}
+	assert.Eventually(t, func() bool { return queueLen() == waiting }, time.Second, time.Millisecond)
+
+	limiter.release()
+	<-acquired
+	assert.Equal(t, waiting-1, queueLen(), "A single release should wake a single waiter")
+
+	for i := 0; i < waiting-1; i++ {
+		limiter.release()
+		<-acquired
+	}
+	limiter.release()
+}
@@ -1,43 +1,194 @@
 package middleware
 
 import (
+	"context"
 	"net/http"
+	"sync/atomic"
 	"time"
 
 	"github.com/rs/zerolog"
 )
 
 // responseWriterInterceptor is a custom wrapper around http.ResponseWriter.
-// It intercepts and records the status code written by downstream handlers.
+// It intercepts and records the status code written by downstream handlers,
+// and whether the response headers have been sent.
 type responseWriterInterceptor struct {
 	http.ResponseWriter
-	statusCode int
+	statusCode  int
+	wroteHeader bool
 }
 
 // newResponseWriterInterceptor creates a new response writer interceptor.
 // Defaults to status code 200 (OK), since handlers that never call WriteHeader
 // implicitly send a 200 OK response.
 func newResponseWriterInterceptor(w http.ResponseWriter) *responseWriterInterceptor {
-	return &responseWriterInterceptor{w, http.StatusOK}
+	return &responseWriterInterceptor{ResponseWriter: w, statusCode: http.StatusOK}
 }
 
 // WriteHeader captures the response status code before delegating
 // the actual header writing to the underlying ResponseWriter.
 func (rwi *responseWriterInterceptor) WriteHeader(code int) {
-	rwi.statusCode = code
+	if !rwi.wroteHeader {
+		rwi.statusCode = code
+		rwi.wroteHeader = true
+	}
 	rwi.ResponseWriter.WriteHeader(code)
 }
 
+// Write records that the headers have been sent, which the first Write does
+// implicitly, before delegating to the underlying ResponseWriter.
+func (rwi *responseWriterInterceptor) Write(b []byte) (int, error) {
+	rwi.wroteHeader = true
+	return rwi.ResponseWriter.Write(b)
+}
+
+// flushed records that flushing sends the headers. Handlers see Flush only
+// when the underlying ResponseWriter supports it, see exposeOptional.
+func (rwi *responseWriterInterceptor) flushed() {
+	rwi.wroteHeader = true
+}
+
+// hijacked records that the connection has been taken over, after which no
+// response may be written.
+func (rwi *responseWriterInterceptor) hijacked() {
+	rwi.wroteHeader = true
+}
+
+// Unwrap returns the underlying ResponseWriter, for http.ResponseController.
+func (rwi *responseWriterInterceptor) Unwrap() http.ResponseWriter {
+	return rwi.ResponseWriter
+}
+
+// logErrorKey is the context key under which Logger stores the request's logErrorSlot.
+type logErrorKey struct{}
+
+// logErrorSlot receives the error recorded by SetLogError for one request.
+type logErrorSlot struct {
+	err atomic.Pointer[error]
+}
+
+// SetLogError records err for inclusion in the access log line that the
+// Logger middleware writes for r, as an "error" field. Later calls replace
+// earlier ones.
+//
+// It is a no-op if r did not pass through Logger. It is safe to call from
+// goroutines spawned by the handler.
+//
+// Example:
+//
+//	if err := store.Save(ctx, item); err != nil {
+//		middleware.SetLogError(r, err)
+//		helpers.SendError(w, http.StatusInternalServerError, "could not save item")
+//		return
+//	}
+func SetLogError(r *http.Request, err error) {
+	if slot, ok := r.Context().Value(logErrorKey{}).(*logErrorSlot); ok {
+		slot.err.Store(&err)
+	}
+}
+
+// loggerConfig holds the settings applied by LoggerOption values.
+type loggerConfig struct {
+	message    string
+	fields     map[string]any
+	sampleRate float64 // Fraction of successful requests to log; 1 logs all.
+	trace      func(ctx context.Context) (traceID, spanID string)
+}
+
+// LoggerOption customizes the Logger middleware.
+type LoggerOption func(*loggerConfig)
+
+// WithMessage replaces the default "Request processed" log message.
+func WithMessage(message string) LoggerOption {
+	return func(c *loggerConfig) {
+		c.message = message
+	}
+}
+
+// WithStaticFields adds the given fields to every request log line, e.g. to
+// tag the service that emitted it. The fields are encoded once, when the
+// middleware is created, so they add no per-request cost.
+func WithStaticFields(fields map[string]any) LoggerOption {
+	return func(c *loggerConfig) {
+		c.fields = fields
+	}
+}
+
+// WithSampling logs only the given fraction (between 0 and 1) of successful
+// requests, to keep log volume manageable on high-traffic endpoints.
+//
+// Requests that fail, i.e. respond with a status of 400 or above or report an
+// error via SetLogError, are always logged. Sampling is deterministic: with a
+// rate of 0.1, exactly one in every ten successful requests is logged. Values
+// of 1 or more log everything; values of 0 or less log failures only.
+func WithSampling(rate float64) LoggerOption {
+	return func(c *loggerConfig) {
+		c.sampleRate = rate
+	}
+}
+
+// WithTraceExtractor adds "trace_id" and "span_id" fields to every log line,
+// as returned by fn for the request's context. fn typically reads the span
+// stored by an upstream tracing middleware, which keeps this package
+// independent of any tracing library. Empty IDs are omitted.
+//
+// Example:
+//
+//	middleware.WithTraceExtractor(func(ctx context.Context) (string, string) {
+//		sc := trace.SpanContextFromContext(ctx)
+//		if !sc.IsValid() {
+//			return "", ""
+//		}
+//		return sc.TraceID().String(), sc.SpanID().String()
+//	})
+func WithTraceExtractor(fn func(ctx context.Context) (traceID, spanID string)) LoggerOption {
+	return func(c *loggerConfig) {
+		c.trace = fn
+	}
+}
+
+// logSampler picks an evenly spaced fraction of requests using a shared counter.
+type logSampler struct {
+	rate float64
+	n    atomic.Uint64
+}
+
+// sample reports whether the current request should be logged.
+func (s *logSampler) sample() bool {
+	n := s.n.Add(1)
+	// Log whenever the running total n*rate crosses an integer.
+	return uint64(float64(n)*s.rate) != uint64(float64(n-1)*s.rate)
+}
+
 // Logger returns an HTTP middleware that provides structured access logging.
 //
 // It leverages zerolog for high-performance, zero-allocation JSON logging.
 // Each request log entry includes method, path, HTTP status code, and latency.
+// Options may add static fields, change the log message, or sample successful
+// requests. Handlers can add an "error" field to their request's line with
+// SetLogError. When RequestID runs before Logger, each line also carries a
+// "request_id" field.
 //
 // Example:
 //
 //	r.Use(middleware.Logger(log))
 //	// Logs: {"level":"info","method":"GET","path":"/api","status":200,"latency_ms":1.23,"message":"Request processed"}
-func Logger(logger zerolog.Logger) func(http.Handler) http.Handler {
+//
+//	r.Use(middleware.Logger(log, middleware.WithStaticFields(map[string]any{"service": "billing"})))
+func Logger(logger zerolog.Logger, opts ...LoggerOption) func(http.Handler) http.Handler {
+	cfg := loggerConfig{message: "Request processed", sampleRate: 1}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	if len(cfg.fields) > 0 {
+		logger = logger.With().Fields(cfg.fields).Logger()
+	}
+
+	var sampler *logSampler
+	if cfg.sampleRate < 1 {
+		sampler = &logSampler{rate: max(cfg.sampleRate, 0)}
+	}
+
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			start := time.Now()
@@ -45,19 +196,45 @@ func Logger(logger zerolog.Logger) func(http.Handler) http.Handler {
 			// Wrap the original ResponseWriter with our interceptor
 			interceptor := newResponseWriterInterceptor(w)
 
+			// Give the handler somewhere to report an error via SetLogError
+			slot := &logErrorSlot{}
+			r = r.WithContext(context.WithValue(r.Context(), logErrorKey{}, slot))
+
 			// Execute the next handler with the wrapped writer
-			next.ServeHTTP(interceptor, r)
+			next.ServeHTTP(exposeOptional(interceptor), r)
 
 			// Measure request latency
 			latency := time.Since(start)
 
+			// Successful requests may be skipped when sampling; failures never are
+			err := slot.err.Load()
+			if sampler != nil && err == nil && interceptor.statusCode < http.StatusBadRequest && !sampler.sample() {
+				return
+			}
+
 			// Log structured request metadata
-			logger.Info().
+			event := logger.Info()
+			if err != nil {
+				event = event.Err(*err)
+			}
+			if id := GetRequestID(r.Context()); id != "" {
+				event = event.Str("request_id", id)
+			}
+			if cfg.trace != nil {
+				traceID, spanID := cfg.trace(r.Context())
+				if traceID != "" {
+					event = event.Str("trace_id", traceID)
+				}
+				if spanID != "" {
+					event = event.Str("span_id", spanID)
+				}
+			}
+			event.
 				Str("method", r.Method).
 				Str("path", r.URL.Path).
 				Int("status", interceptor.statusCode).
 				Dur("latency_ms", latency).
-				Msg("Request processed")
+				Msg(cfg.message)
 		})
 	}
 }
@@ -2,6 +2,8 @@ package middleware
 
 import (
 	"bytes"
+	"context"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"strings"
@@ -42,3 +44,141 @@ func TestLogger(t *testing.T) {
 	assert.True(t, strings.Contains(logString, `"latency_ms"`), "Log should contain latency field")
 
 }
+
+func TestLogger_MessageAndStaticFields(t *testing.T) {
+	logOutput := &bytes.Buffer{}
+
+	logger := zerolog.New(logOutput)
+
+	loggerMiddleware := Logger(logger,
+		WithMessage("Handled"),
+		WithStaticFields(map[string]any{"service": "billing", "shard": 3}),
+	)
+
+	mockHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("OK"))
+	})
+
+	handlerToTest := loggerMiddleware(mockHandler)
+
+	for i := 0; i < 2; i++ {
+		req := httptest.NewRequest("GET", "/testpath", nil)
+		rr := httptest.NewRecorder()
+		handlerToTest.ServeHTTP(rr, req)
+	}
+
+	lines := strings.Split(strings.TrimSpace(logOutput.String()), "\n")
+	assert.Len(t, lines, 2)
+	for _, line := range lines {
+		assert.Contains(t, line, `"message":"Handled"`)
+		assert.Contains(t, line, `"service":"billing"`)
+		assert.Contains(t, line, `"shard":3`)
+		assert.Contains(t, line, `"status":200`)
+		assert.Equal(t, 1, strings.Count(line, `"service"`), "Static fields should not accumulate")
+	}
+}
+
+func TestLogger_SetLogError(t *testing.T) {
+	logOutput := &bytes.Buffer{}
+
+	logger := zerolog.New(logOutput)
+
+	mockHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/fail" {
+			SetLogError(r, errors.New("db timeout"))
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	})
+
+	handlerToTest := Logger(logger)(mockHandler)
+
+	handlerToTest.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/fail", nil))
+	handlerToTest.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/ok", nil))
+
+	lines := strings.Split(strings.TrimSpace(logOutput.String()), "\n")
+	assert.Len(t, lines, 2)
+	assert.Contains(t, lines[0], `"error":"db timeout"`)
+	assert.Contains(t, lines[0], `"status":500`)
+	assert.NotContains(t, lines[1], `"error"`, "Errors must not leak between requests")
+}
+
+func TestSetLogError_WithoutLogger(t *testing.T) {
+	req := httptest.NewRequest("GET", "/", nil)
+
+	assert.NotPanics(t, func() { SetLogError(req, errors.New("ignored")) })
+}
+
+func TestLogger_WithSampling(t *testing.T) {
+	logOutput := &bytes.Buffer{}
+
+	logger := zerolog.New(logOutput)
+
+	mockHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/boom":
+			w.WriteHeader(http.StatusInternalServerError)
+		case "/seterr":
+			SetLogError(r, errors.New("soft failure"))
+		}
+	})
+
+	handlerToTest := Logger(logger, WithSampling(0.25))(mockHandler)
+
+	for i := 0; i < 100; i++ {
+		handlerToTest.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/ok", nil))
+	}
+	handlerToTest.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/boom", nil))
+	handlerToTest.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/seterr", nil))
+
+	logString := logOutput.String()
+	assert.Equal(t, 25, strings.Count(logString, `"path":"/ok"`), "A quarter of successful requests should be logged")
+	assert.Equal(t, 1, strings.Count(logString, `"path":"/boom"`), "5xx responses should always be logged")
+	assert.Equal(t, 1, strings.Count(logString, `"path":"/seterr"`), "Requests with an error should always be logged")
+}
+
+func TestLogger_WithTraceExtractor(t *testing.T) {
+	logOutput := &bytes.Buffer{}
+
+	logger := zerolog.New(logOutput)
+
+	type traceKey struct{}
+	extractor := func(ctx context.Context) (string, string) {
+		ids, _ := ctx.Value(traceKey{}).([2]string)
+		return ids[0], ids[1]
+	}
+
+	mockHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})
+	handlerToTest := Logger(logger, WithTraceExtractor(extractor))(mockHandler)
+
+	req := httptest.NewRequest("GET", "/traced", nil)
+	req = req.WithContext(context.WithValue(req.Context(), traceKey{}, [2]string{"4bf92f3577b34da6", "00f067aa0ba902b7"}))
+	handlerToTest.ServeHTTP(httptest.NewRecorder(), req)
+	handlerToTest.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/untraced", nil))
+
+	lines := strings.Split(strings.TrimSpace(logOutput.String()), "\n")
+	assert.Len(t, lines, 2)
+	assert.Contains(t, lines[0], `"trace_id":"4bf92f3577b34da6"`)
+	assert.Contains(t, lines[0], `"span_id":"00f067aa0ba902b7"`)
+	assert.NotContains(t, lines[1], "trace_id", "Empty IDs should be omitted")
+	assert.NotContains(t, lines[1], "span_id")
+}
+
+func TestLogger_RequestID(t *testing.T) {
+	logOutput := &bytes.Buffer{}
+
+	logger := zerolog.New(logOutput)
+
+	mockHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})
+	withID := RequestID()(Logger(logger)(mockHandler))
+	withoutID := Logger(logger)(mockHandler)
+
+	req := httptest.NewRequest("GET", "/", nil)
+	req.Header.Set(RequestIDHeader, "req-42")
+	withID.ServeHTTP(httptest.NewRecorder(), req)
+	withoutID.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/", nil))
+
+	lines := strings.Split(strings.TrimSpace(logOutput.String()), "\n")
+	assert.Len(t, lines, 2)
+	assert.Contains(t, lines[0], `"request_id":"req-42"`)
+	assert.NotContains(t, lines[1], "request_id", "The field should be omitted without RequestID")
+}
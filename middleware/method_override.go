@@ -0,0 +1,61 @@
+package middleware
+
+import (
+	"net/http"
+	"strings"
+)
+
+// DefaultMethodOverrideField is the form field consulted by MethodOverride.
+const DefaultMethodOverrideField = "_method"
+
+// overridableMethods lists the methods a POST request may be rewritten to.
+var overridableMethods = map[string]bool{
+	http.MethodPut:    true,
+	http.MethodPatch:  true,
+	http.MethodDelete: true,
+}
+
+// MethodOverride returns an HTTP middleware that lets POST requests declare
+// the method they stand in for, for clients such as HTML forms that cannot
+// send PUT, PATCH, or DELETE directly.
+//
+// The override is read from the X-HTTP-Method-Override header or, if that is
+// absent, from the "_method" form field. It is applied only to POST requests
+// and only when it names PUT, PATCH, or DELETE (case-insensitively); any other
+// value is ignored and the request passes through unchanged.
+//
+// Example:
+//
+//	handler := middleware.MethodOverride()(router)
+//	// <form method="POST" action="/posts/1"><input type="hidden" name="_method" value="DELETE"></form>
+func MethodOverride() func(http.Handler) http.Handler {
+	return MethodOverrideField(DefaultMethodOverrideField)
+}
+
+// MethodOverrideField behaves like MethodOverride, but reads the override
+// from the given form field instead of "_method".
+//
+// Panics if field is empty.
+func MethodOverrideField(field string) func(http.Handler) http.Handler {
+	if field == "" {
+		panic("middleware.MethodOverrideField: field must not be empty")
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Method == http.MethodPost {
+				override := r.Header.Get("X-HTTP-Method-Override")
+				if override == "" {
+					// Only form-encoded bodies are parsed; other bodies are left untouched.
+					override = r.PostFormValue(field)
+				}
+
+				if method := strings.ToUpper(override); overridableMethods[method] {
+					r.Method = method
+				}
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
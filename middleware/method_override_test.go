@@ -0,0 +1,82 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func methodEcho() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(r.Method))
+	})
+}
+
+func TestMethodOverride_Header(t *testing.T) {
+	handlerToTest := MethodOverride()(methodEcho())
+
+	req := httptest.NewRequest("POST", "/posts/1", nil)
+	req.Header.Set("X-HTTP-Method-Override", "patch")
+	rr := httptest.NewRecorder()
+
+	handlerToTest.ServeHTTP(rr, req)
+
+	assert.Equal(t, "PATCH", rr.Body.String())
+}
+
+func TestMethodOverride_FormField(t *testing.T) {
+	handlerToTest := MethodOverride()(methodEcho())
+
+	req := httptest.NewRequest("POST", "/posts/1", strings.NewReader("_method=DELETE&title=x"))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	rr := httptest.NewRecorder()
+
+	handlerToTest.ServeHTTP(rr, req)
+
+	assert.Equal(t, "DELETE", rr.Body.String())
+}
+
+func TestMethodOverrideField_CustomField(t *testing.T) {
+	handlerToTest := MethodOverrideField("verb")(methodEcho())
+
+	req := httptest.NewRequest("POST", "/posts/1", strings.NewReader("verb=put"))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	rr := httptest.NewRecorder()
+
+	handlerToTest.ServeHTTP(rr, req)
+
+	assert.Equal(t, "PUT", rr.Body.String())
+}
+
+func TestMethodOverride_RejectsDisallowedMethods(t *testing.T) {
+	handlerToTest := MethodOverride()(methodEcho())
+
+	for _, method := range []string{"GET", "CONNECT", "TRACE", "bogus"} {
+		req := httptest.NewRequest("POST", "/", nil)
+		req.Header.Set("X-HTTP-Method-Override", method)
+		rr := httptest.NewRecorder()
+
+		handlerToTest.ServeHTTP(rr, req)
+
+		assert.Equal(t, "POST", rr.Body.String(), "Override to %s should be ignored", method)
+	}
+}
+
+func TestMethodOverride_OnlyPost(t *testing.T) {
+	handlerToTest := MethodOverride()(methodEcho())
+
+	req := httptest.NewRequest("GET", "/", nil)
+	req.Header.Set("X-HTTP-Method-Override", "DELETE")
+	rr := httptest.NewRecorder()
+
+	handlerToTest.ServeHTTP(rr, req)
+
+	assert.Equal(t, "GET", rr.Body.String())
+}
+
+func TestMethodOverrideField_PanicsOnEmptyField(t *testing.T) {
+	assert.Panics(t, func() { MethodOverrideField("") })
+}
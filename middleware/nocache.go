@@ -0,0 +1,32 @@
+package middleware
+
+import "net/http"
+
+// NoCache returns an HTTP middleware that marks every response as
+// non-cacheable by browsers and intermediate proxies.
+//
+// It sets the following headers before calling the next handler, so they are
+// present no matter how the handler writes its response:
+//
+//	Cache-Control: no-store, no-cache, must-revalidate
+//	Pragma: no-cache
+//	Expires: 0
+//
+// Use it for endpoints serving authentication state or other data that must
+// always be fetched fresh.
+//
+// Example:
+//
+//	mux.Handle("/api/me", middleware.NoCache()(meHandler))
+func NoCache() func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			h := w.Header()
+			h.Set("Cache-Control", "no-store, no-cache, must-revalidate")
+			h.Set("Pragma", "no-cache")
+			h.Set("Expires", "0")
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,47 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNoCache(t *testing.T) {
+	mockHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("fresh"))
+	})
+
+	handlerToTest := NoCache()(mockHandler)
+
+	req := httptest.NewRequest("GET", "/", nil)
+	rr := httptest.NewRecorder()
+
+	handlerToTest.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, "fresh", rr.Body.String())
+	assert.Equal(t, "no-store, no-cache, must-revalidate", rr.Header().Get("Cache-Control"))
+	assert.Equal(t, "no-cache", rr.Header().Get("Pragma"))
+	assert.Equal(t, "0", rr.Header().Get("Expires"))
+}
+
+func TestNoCache_HeadersPresentBeforeWrite(t *testing.T) {
+	var seen string
+	mockHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		seen = w.Header().Get("Cache-Control")
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	handlerToTest := NoCache()(mockHandler)
+
+	req := httptest.NewRequest("GET", "/", nil)
+	rr := httptest.NewRecorder()
+
+	handlerToTest.ServeHTTP(rr, req)
+
+	assert.Equal(t, "no-store, no-cache, must-revalidate", seen, "Headers should be set before the handler runs")
+	assert.Equal(t, http.StatusNoContent, rr.Code)
+	assert.Equal(t, "0", rr.Header().Get("Expires"))
+}
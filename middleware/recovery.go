@@ -4,8 +4,47 @@ import (
 	"log"
 	"net/http"
 	"runtime/debug"
+	"sync/atomic"
 )
 
+// PanicCounter counts the panics recovered by Recovery middleware it is
+// attached to with WithPanicCounter. It is safe for concurrent use; the zero
+// value is ready to use.
+type PanicCounter struct {
+	n atomic.Uint64
+}
+
+// PanicCount returns the number of panics recovered so far.
+func (c *PanicCounter) PanicCount() uint64 {
+	return c.n.Load()
+}
+
+// recoveryConfig holds the settings applied by RecoveryOption values.
+type recoveryConfig struct {
+	counter *PanicCounter
+	onPanic func(recovered any)
+}
+
+// RecoveryOption customizes the Recovery middleware.
+type RecoveryOption func(*recoveryConfig)
+
+// WithPanicCounter makes Recovery increment c for every panic it recovers,
+// e.g. to export a panic-rate metric. One counter may be shared by several
+// Recovery instances.
+func WithPanicCounter(c *PanicCounter) RecoveryOption {
+	return func(cfg *recoveryConfig) {
+		cfg.counter = c
+	}
+}
+
+// WithOnPanic registers fn to be called with the recovered value for every
+// panic, after it has been logged. fn must be safe for concurrent use.
+func WithOnPanic(fn func(recovered any)) RecoveryOption {
+	return func(cfg *recoveryConfig) {
+		cfg.onPanic = fn
+	}
+}
+
 // Recovery returns an HTTP middleware that recovers from panics
 // in downstream handlers and logs the error details.
 //
@@ -14,6 +53,10 @@ import (
 // full stack trace using the provided *log.Logger, then returns a
 // safe 500 Internal Server Error response to the client.
 //
+// If the handler had already started the response, e.g. a streaming handler
+// that panics mid-body, the status can no longer be changed, so the panic is
+// only logged and no error response is written.
+//
 // Example:
 //
 //	logger := log.New(os.Stderr, "", log.LstdFlags)
@@ -24,9 +67,21 @@ import (
 //	PANIC: runtime error: index out of range
 //	goroutine 18 [running]:
 //	...stack trace...
-func Recovery(logger *log.Logger) func(http.Handler) http.Handler {
+//
+// Options can additionally count recovered panics or report them to a callback:
+//
+//	var panics middleware.PanicCounter
+//	r.Use(middleware.Recovery(logger, middleware.WithPanicCounter(&panics)))
+func Recovery(logger *log.Logger, opts ...RecoveryOption) func(http.Handler) http.Handler {
+	var cfg recoveryConfig
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			// Track whether the handler has already sent the headers
+			interceptor := newResponseWriterInterceptor(w)
 
 			// Defer a panic recovery function
 			defer func() {
@@ -34,14 +89,26 @@ func Recovery(logger *log.Logger) func(http.Handler) http.Handler {
 					// Log the panic message and full stack trace
 					logger.Printf("PANIC: %v\n\n%s", err, debug.Stack())
 
+					if cfg.counter != nil {
+						cfg.counter.n.Add(1)
+					}
+					if cfg.onPanic != nil {
+						cfg.onPanic(err)
+					}
+
+					// Too late for an error response once the headers are out
+					if interceptor.wroteHeader {
+						logger.Printf("PANIC: response already started with status %d; not sending 500", interceptor.statusCode)
+						return
+					}
+
 					// Send a generic 500 response to the client.
-					// Safe to call even if headers were partially written.
 					http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
 				}
 			}()
 
 			// Continue to the next handler
-			next.ServeHTTP(w, r)
+			next.ServeHTTP(exposeOptional(interceptor), r)
 		})
 	}
 }
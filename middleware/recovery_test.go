@@ -1,10 +1,14 @@
 package middleware
 
 import (
+	"bufio"
 	"io"
 	"log"
+	"net"
 	"net/http"
 	"net/http/httptest"
+	"strings"
+	"sync"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -52,3 +56,142 @@ func TestRecovery_WithPanic(t *testing.T) {
 	assert.Equal(t, http.StatusInternalServerError, rr.Code, "Status code should be 500 Internal Server Error")
 	assert.Equal(t, http.StatusText(http.StatusInternalServerError)+"\n", rr.Body.String(), "Response body should match the default 500 error text")
 }
+
+func TestRecovery_PanicCounterAndCallback(t *testing.T) {
+	logger := log.New(io.Discard, "", 0)
+
+	var counter PanicCounter
+	var mu sync.Mutex
+	var recovered []any
+
+	recoveryMiddleware := Recovery(logger,
+		WithPanicCounter(&counter),
+		WithOnPanic(func(v any) {
+			mu.Lock()
+			recovered = append(recovered, v)
+			mu.Unlock()
+		}),
+	)
+
+	mockHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/panic" {
+			panic("boom")
+		}
+	})
+
+	handlerToTest := recoveryMiddleware(mockHandler)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			path := "/ok"
+			if i%2 == 0 {
+				path = "/panic"
+			}
+			handlerToTest.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", path, nil))
+		}(i)
+	}
+	wg.Wait()
+
+	assert.Equal(t, uint64(5), counter.PanicCount(), "Only panicking requests should be counted")
+	assert.Len(t, recovered, 5)
+	assert.Equal(t, "boom", recovered[0])
+}
+
+// headerCountingRecorder counts WriteHeader calls, which a real server would
+// report as "superfluous" beyond the first.
+type headerCountingRecorder struct {
+	*httptest.ResponseRecorder
+	writeHeaderCalls int
+}
+
+func (h *headerCountingRecorder) WriteHeader(code int) {
+	h.writeHeaderCalls++
+	h.ResponseRecorder.WriteHeader(code)
+}
+
+func TestRecovery_PanicAfterHeadersWritten(t *testing.T) {
+	var logOutput strings.Builder
+	logger := log.New(&logOutput, "", 0)
+
+	handlerToTest := Recovery(logger)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte("partial"))
+		w.(http.Flusher).Flush()
+		panic("mid-stream failure")
+	}))
+
+	rr := &headerCountingRecorder{ResponseRecorder: httptest.NewRecorder()}
+	assert.NotPanics(t, func() {
+		handlerToTest.ServeHTTP(rr, httptest.NewRequest("GET", "/", nil))
+	})
+
+	assert.Equal(t, 1, rr.writeHeaderCalls, "Recovery should not write a second status")
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, "partial", rr.Body.String(), "No error text should be appended to the streamed body")
+	assert.True(t, rr.Flushed, "Flush should reach the underlying writer")
+	assert.Contains(t, logOutput.String(), "PANIC: mid-stream failure")
+	assert.Contains(t, logOutput.String(), "response already started")
+}
+
+func TestRecovery_PanicAfterImplicitHeaders(t *testing.T) {
+	logger := log.New(io.Discard, "", 0)
+
+	handlerToTest := Recovery(logger)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("partial"))
+		panic("boom")
+	}))
+
+	rr := &headerCountingRecorder{ResponseRecorder: httptest.NewRecorder()}
+	handlerToTest.ServeHTTP(rr, httptest.NewRequest("GET", "/", nil))
+
+	assert.Equal(t, 0, rr.writeHeaderCalls, "The implicit 200 from Write should not be followed by a 500")
+	assert.Equal(t, "partial", rr.Body.String())
+}
+
+// hijackableRecorder is a ResponseRecorder whose connection can be hijacked.
+type hijackableRecorder struct {
+	*httptest.ResponseRecorder
+	hijacked bool
+}
+
+func (h *hijackableRecorder) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	h.hijacked = true
+	return nil, nil, nil
+}
+
+func TestRecovery_PreservesHijacker(t *testing.T) {
+	logger := log.New(io.Discard, "", 0)
+
+	handlerToTest := Recovery(logger)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hj, ok := w.(http.Hijacker)
+		if !assert.True(t, ok, "Hijacker should stay visible through Recovery") {
+			return
+		}
+		hj.Hijack()
+		panic("after upgrade")
+	}))
+
+	rr := &hijackableRecorder{ResponseRecorder: httptest.NewRecorder()}
+	handlerToTest.ServeHTTP(rr, httptest.NewRequest("GET", "/", nil))
+
+	assert.True(t, rr.hijacked, "Hijack should reach the underlying writer")
+	assert.Empty(t, rr.Body.String(), "No 500 should be written to a hijacked connection")
+}
+
+func TestRecovery_HidesMissingFlusher(t *testing.T) {
+	logger := log.New(io.Discard, "", 0)
+
+	var canFlush bool
+	handlerToTest := Recovery(logger)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, canFlush = w.(http.Flusher)
+	}))
+
+	// Embedding the interface hides the recorder's Flush method.
+	rr := struct{ http.ResponseWriter }{httptest.NewRecorder()}
+	handlerToTest.ServeHTTP(rr, httptest.NewRequest("GET", "/", nil))
+
+	assert.False(t, canFlush, "Flusher should not be advertised when the underlying writer cannot flush")
+}
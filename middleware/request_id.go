@@ -0,0 +1,78 @@
+package middleware
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+
+	"github.com/iameggi/cassie/helpers"
+)
+
+// RequestIDHeader is the header RequestID reads incoming IDs from and echoes
+// the request's ID in.
+const RequestIDHeader = "X-Request-ID"
+
+// maxRequestIDLen bounds the length of incoming request IDs that are trusted.
+const maxRequestIDLen = 128
+
+// RequestIDKey is the context key under which RequestID stores the request's
+// ID, as a string. Prefer GetRequestID for reading it.
+//
+// It is the same key as helpers.RequestIDKey, so helpers.SendErrorWithRequest
+// picks the ID up.
+var RequestIDKey = helpers.RequestIDKey
+
+// GetRequestID returns the request ID stored in ctx by RequestID, or "".
+func GetRequestID(ctx context.Context) string {
+	id, _ := ctx.Value(RequestIDKey).(string)
+	return id
+}
+
+// RequestID returns an HTTP middleware that assigns every request an ID for
+// correlating logs across services.
+//
+// An ID sent by the client or an upstream proxy in the X-Request-ID header is
+// reused if it is at most 128 printable ASCII characters long; otherwise a
+// random 32-character hex ID is generated. The ID is stored in the request
+// context under RequestIDKey and echoed in the X-Request-ID response header.
+//
+// Logger includes it as a "request_id" field when RequestID runs before it.
+//
+// Example:
+//
+//	handler := middleware.RequestID()(middleware.Logger(log)(router))
+func RequestID() func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			id := r.Header.Get(RequestIDHeader)
+			if !validRequestID(id) {
+				id = newRequestID()
+			}
+
+			w.Header().Set(RequestIDHeader, id)
+			next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), RequestIDKey, id)))
+		})
+	}
+}
+
+// validRequestID reports whether an incoming ID is safe to reuse in headers and logs.
+func validRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLen {
+		return false
+	}
+	for i := 0; i < len(id); i++ {
+		if id[i] < 0x21 || id[i] > 0x7e {
+			return false
+		}
+	}
+	return true
+}
+
+// newRequestID returns a random hex-encoded 128-bit ID.
+func newRequestID() string {
+	b := make([]byte, 16)
+	// crypto/rand.Read never returns an error on supported platforms.
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,76 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/iameggi/cassie/helpers"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRequestID_Generates(t *testing.T) {
+	var seen string
+	handler := RequestID()(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		seen = GetRequestID(r.Context())
+	}))
+
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, httptest.NewRequest("GET", "/", nil))
+
+	assert.Len(t, seen, 32, "A 128-bit hex ID should be generated")
+	assert.Equal(t, seen, rr.Header().Get(RequestIDHeader), "The ID should be echoed in the response")
+
+	first := seen
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/", nil))
+	assert.NotEqual(t, first, seen, "Each request should get a fresh ID")
+}
+
+func TestRequestID_ReusesIncoming(t *testing.T) {
+	var seen string
+	handler := RequestID()(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		seen = GetRequestID(r.Context())
+	}))
+
+	req := httptest.NewRequest("GET", "/", nil)
+	req.Header.Set(RequestIDHeader, "abc-123")
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+
+	assert.Equal(t, "abc-123", seen)
+	assert.Equal(t, "abc-123", rr.Header().Get(RequestIDHeader))
+}
+
+func TestRequestID_RejectsInvalidIncoming(t *testing.T) {
+	var seen string
+	handler := RequestID()(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		seen = GetRequestID(r.Context())
+	}))
+
+	for _, id := range []string{"has space", strings.Repeat("x", 129), "tab\tinside"} {
+		req := httptest.NewRequest("GET", "/", nil)
+		req.Header.Set(RequestIDHeader, id)
+		handler.ServeHTTP(httptest.NewRecorder(), req)
+
+		assert.NotEqual(t, id, seen, "Invalid IDs should be replaced")
+		assert.Len(t, seen, 32)
+	}
+}
+
+func TestGetRequestID_Absent(t *testing.T) {
+	assert.Equal(t, "", GetRequestID(httptest.NewRequest("GET", "/", nil).Context()))
+}
+
+func TestRequestID_SendErrorWithRequest(t *testing.T) {
+	handler := RequestID()(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		helpers.SendErrorWithRequest(w, r, http.StatusNotFound, "missing")
+	}))
+
+	req := httptest.NewRequest("GET", "/", nil)
+	req.Header.Set(RequestIDHeader, "trace-42")
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+
+	assert.JSONEq(t, `{"error":"missing","request_id":"trace-42"}`, rr.Body.String())
+}
@@ -0,0 +1,78 @@
+package middleware
+
+import (
+	"net/http"
+	"strings"
+)
+
+// StripSlashes returns an HTTP middleware that removes trailing slashes from
+// the request path before passing the request on, so "/users/" is routed the
+// same as "/users".
+//
+// The root path "/" is left untouched, and the query string is preserved.
+//
+// Example:
+//
+//	handler := middleware.StripSlashes()(router)
+func StripSlashes() func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if path, ok := trimTrailingSlashes(r.URL.Path); ok {
+				r.URL.Path = path
+				if r.URL.RawPath != "" {
+					r.URL.RawPath, _ = trimTrailingSlashes(r.URL.RawPath)
+				}
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// RedirectSlashes returns an HTTP middleware that redirects requests whose
+// path ends in a slash to the canonical form without it, using
+// 301 Moved Permanently.
+//
+// The root path "/" is left untouched, and the query string is carried over
+// to the redirect target.
+//
+// Example:
+//
+//	handler := middleware.RedirectSlashes()(router)
+//	// GET /users/?page=2  ->  301 Location: /users?page=2
+func RedirectSlashes() func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			path, ok := trimTrailingSlashes(r.URL.EscapedPath())
+			if !ok {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			if r.URL.RawQuery != "" {
+				path += "?" + r.URL.RawQuery
+			}
+			http.Redirect(w, r, path, http.StatusMovedPermanently)
+		})
+	}
+}
+
+// trimTrailingSlashes strips trailing slashes from path and reports whether
+// anything changed. The root path is never modified.
+//
+// A result starting with "//" is collapsed to a single leading slash, so the
+// returned path can never be mistaken for a protocol-relative URL.
+func trimTrailingSlashes(path string) (string, bool) {
+	if len(path) <= 1 || path[len(path)-1] != '/' {
+		return path, false
+	}
+
+	trimmed := strings.TrimRight(path, "/")
+	if trimmed == "" {
+		return path, false
+	}
+	if strings.HasPrefix(trimmed, "//") {
+		trimmed = "/" + strings.TrimLeft(trimmed, "/")
+	}
+	return trimmed, true
+}
@@ -0,0 +1,72 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func pathEcho() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(r.URL.Path + "?" + r.URL.RawQuery))
+	})
+}
+
+func TestStripSlashes(t *testing.T) {
+	handlerToTest := StripSlashes()(pathEcho())
+
+	tests := map[string]string{
+		"/users/?page=2": "/users?page=2",
+		"/users//":       "/users?",
+		"/users":         "/users?",
+		"/":              "/?",
+	}
+	for target, want := range tests {
+		req := httptest.NewRequest("GET", target, nil)
+		rr := httptest.NewRecorder()
+
+		handlerToTest.ServeHTTP(rr, req)
+
+		assert.Equal(t, want, rr.Body.String(), "Unexpected path for %s", target)
+	}
+}
+
+func TestRedirectSlashes(t *testing.T) {
+	handlerToTest := RedirectSlashes()(pathEcho())
+
+	req := httptest.NewRequest("GET", "/users/?page=2", nil)
+	rr := httptest.NewRecorder()
+
+	handlerToTest.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusMovedPermanently, rr.Code)
+	assert.Equal(t, "/users?page=2", rr.Header().Get("Location"))
+}
+
+func TestRedirectSlashes_PassesCanonicalPaths(t *testing.T) {
+	handlerToTest := RedirectSlashes()(pathEcho())
+
+	for _, target := range []string{"/", "/users"} {
+		req := httptest.NewRequest("GET", target, nil)
+		rr := httptest.NewRecorder()
+
+		handlerToTest.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code, "%s should not be redirected", target)
+		assert.Equal(t, target+"?", rr.Body.String())
+	}
+}
+
+func TestRedirectSlashes_NoOpenRedirect(t *testing.T) {
+	handlerToTest := RedirectSlashes()(pathEcho())
+
+	req := httptest.NewRequest("GET", "//evil.example/", nil)
+	rr := httptest.NewRecorder()
+
+	handlerToTest.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusMovedPermanently, rr.Code)
+	assert.Equal(t, "/evil.example", rr.Header().Get("Location"))
+}
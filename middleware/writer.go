@@ -0,0 +1,72 @@
+package middleware
+
+import (
+	"bufio"
+	"net"
+	"net/http"
+)
+
+// wrappedWriter is a ResponseWriter that wraps another one to observe the
+// response, such as responseWriterInterceptor or cacheRecorder.
+//
+// Wrapping hides the optional interfaces of the underlying writer, so
+// wrappers are handed to handlers through exposeOptional, which restores
+// http.Flusher and http.Hijacker exactly when the underlying writer has them.
+type wrappedWriter interface {
+	http.ResponseWriter
+	Unwrap() http.ResponseWriter
+
+	// flushed is called before the underlying writer is flushed.
+	flushed()
+	// hijacked is called before the connection is hijacked.
+	hijacked()
+}
+
+// flushWriter adds http.Flusher to a wrappedWriter.
+type flushWriter struct{ wrappedWriter }
+
+// Flush flushes the underlying writer.
+func (w flushWriter) Flush() {
+	w.flushed()
+	w.Unwrap().(http.Flusher).Flush()
+}
+
+// hijackWriter adds http.Hijacker to a wrappedWriter.
+type hijackWriter struct{ wrappedWriter }
+
+// Hijack hijacks the underlying connection.
+func (w hijackWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	w.hijacked()
+	return w.Unwrap().(http.Hijacker).Hijack()
+}
+
+// flushHijackWriter adds both http.Flusher and http.Hijacker to a wrappedWriter.
+type flushHijackWriter struct{ wrappedWriter }
+
+// Flush flushes the underlying writer.
+func (w flushHijackWriter) Flush() {
+	flushWriter(w).Flush()
+}
+
+// Hijack hijacks the underlying connection.
+func (w flushHijackWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	return hijackWriter(w).Hijack()
+}
+
+// exposeOptional returns w extended with the http.Flusher and http.Hijacker
+// implementations of the writer it wraps, if any.
+func exposeOptional(w wrappedWriter) http.ResponseWriter {
+	_, canFlush := w.Unwrap().(http.Flusher)
+	_, canHijack := w.Unwrap().(http.Hijacker)
+
+	switch {
+	case canFlush && canHijack:
+		return flushHijackWriter{w}
+	case canFlush:
+		return flushWriter{w}
+	case canHijack:
+		return hijackWriter{w}
+	default:
+		return w
+	}
+}
@@ -0,0 +1,84 @@
+package weave
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// Batch splits items into consecutive batches of batchSize and processes
+// them concurrently, running at most concurrency batch handlers at once.
+//
+// The final batch holds the remaining items and may be shorter than
+// batchSize. Each batch is a subslice of items, so fn must not retain or
+// append to it beyond its own call.
+//
+// The first error returned (or panic raised) by fn cancels the context
+// passed to the remaining handlers, stops scheduling new batches, and is
+// returned once all running handlers have finished. If the parent context
+// is canceled, Batch stops scheduling and returns ctx.Err().
+//
+// Returns an error if batchSize or concurrency is less than or equal to zero.
+func Batch[T any](ctx context.Context, items []T, batchSize, concurrency int, fn func(ctx context.Context, batch []T) error) error {
+	if batchSize <= 0 {
+		return errors.New("weave: batchSize must be greater than 0")
+	}
+	if concurrency <= 0 {
+		return errors.New("weave: concurrency must be greater than 0")
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		wg       sync.WaitGroup
+		once     sync.Once
+		firstErr error
+		sem      = make(chan struct{}, concurrency)
+	)
+
+	fail := func(err error) {
+		once.Do(func() {
+			firstErr = err
+			cancel()
+		})
+	}
+
+schedule:
+	for start := 0; start < len(items); start += batchSize {
+		end := min(start+batchSize, len(items))
+
+		// Acquire a slot, giving up if the run has been canceled.
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			break schedule
+		}
+		if ctx.Err() != nil {
+			<-sem
+			break
+		}
+
+		wg.Add(1)
+		go func(batch []T) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			defer func() {
+				if r := recover(); r != nil {
+					fail(&PanicError{value: r})
+				}
+			}()
+
+			if err := fn(ctx, batch); err != nil {
+				fail(err)
+			}
+		}(items[start:end:end])
+	}
+
+	wg.Wait()
+
+	if firstErr != nil {
+		return firstErr
+	}
+	return ctx.Err()
+}
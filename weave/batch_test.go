@@ -0,0 +1,89 @@
+package weave
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestBatch_Success verifies that items are split into batches, including a final partial batch.
+func TestBatch_Success(t *testing.T) {
+	items := []int{1, 2, 3, 4, 5, 6, 7}
+
+	var mu sync.Mutex
+	var batches [][]int
+	err := Batch(context.Background(), items, 3, 2, func(ctx context.Context, batch []int) error {
+		mu.Lock()
+		defer mu.Unlock()
+		batches = append(batches, append([]int(nil), batch...))
+		return nil
+	})
+
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, [][]int{{1, 2, 3}, {4, 5, 6}, {7}}, batches)
+}
+
+// TestBatch_InvalidArgs ensures Batch validates batchSize and concurrency.
+func TestBatch_InvalidArgs(t *testing.T) {
+	noop := func(ctx context.Context, batch []int) error { return nil }
+
+	err := Batch(context.Background(), []int{1}, 0, 1, noop)
+	assert.ErrorContains(t, err, "batchSize must be greater than 0")
+
+	err = Batch(context.Background(), []int{1}, 1, 0, noop)
+	assert.ErrorContains(t, err, "concurrency must be greater than 0")
+}
+
+// TestBatch_ConcurrencyLimit ensures no more than concurrency handlers run at once.
+func TestBatch_ConcurrencyLimit(t *testing.T) {
+	items := make([]int, 20)
+
+	var active, maxActive int32
+	err := Batch(context.Background(), items, 2, 3, func(ctx context.Context, batch []int) error {
+		current := atomic.AddInt32(&active, 1)
+		for {
+			prev := atomic.LoadInt32(&maxActive)
+			if current <= prev || atomic.CompareAndSwapInt32(&maxActive, prev, current) {
+				break
+			}
+		}
+		time.Sleep(5 * time.Millisecond)
+		atomic.AddInt32(&active, -1)
+		return nil
+	})
+
+	assert.NoError(t, err)
+	assert.LessOrEqual(t, atomic.LoadInt32(&maxActive), int32(3))
+}
+
+// TestBatch_ErrorCancelsRest verifies that the first error cancels other handlers and stops scheduling.
+func TestBatch_ErrorCancelsRest(t *testing.T) {
+	items := make([]int, 100)
+	expectedErr := errors.New("batch failed")
+
+	var started int32
+	err := Batch(context.Background(), items, 1, 2, func(ctx context.Context, batch []int) error {
+		if atomic.AddInt32(&started, 1) == 1 {
+			return expectedErr
+		}
+		<-ctx.Done()
+		return ctx.Err()
+	})
+
+	assert.Equal(t, expectedErr, err)
+	assert.Less(t, atomic.LoadInt32(&started), int32(100), "Scheduling should stop after the first error")
+}
+
+// TestBatch_Panic verifies that handler panics are recovered and returned as errors.
+func TestBatch_Panic(t *testing.T) {
+	err := Batch(context.Background(), []int{1, 2}, 1, 1, func(ctx context.Context, batch []int) error {
+		panic("batch panic")
+	})
+
+	assert.ErrorContains(t, err, "panic recovered: batch panic")
+}
@@ -0,0 +1,49 @@
+package weave
+
+import (
+	"context"
+	"sync"
+)
+
+// Collect fans in values from all input channels into a single output channel.
+//
+// The output channel is unbuffered and is closed once every input channel
+// has been closed, or once ctx is done. Values from a single input keep their
+// relative order; there is no ordering guarantee across inputs.
+//
+// After ctx is done, values still pending in the inputs are not drained, so
+// producers must observe the same context to avoid blocking forever.
+func Collect[T any](ctx context.Context, chans ...<-chan T) <-chan T {
+	out := make(chan T)
+
+	var wg sync.WaitGroup
+	wg.Add(len(chans))
+	for _, ch := range chans {
+		go func(ch <-chan T) {
+			defer wg.Done()
+			for {
+				select {
+				case v, ok := <-ch:
+					if !ok {
+						return
+					}
+					select {
+					case out <- v:
+					case <-ctx.Done():
+						return
+					}
+				case <-ctx.Done():
+					return
+				}
+			}
+		}(ch)
+	}
+
+	// Close the output once every forwarder has exited.
+	go func() {
+		wg.Wait()
+		close(out)
+	}()
+
+	return out
+}
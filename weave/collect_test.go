@@ -0,0 +1,43 @@
+package weave
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestCollect_MergesAll verifies that every value from every input is forwarded.
+func TestCollect_MergesAll(t *testing.T) {
+	out := Collect(context.Background(), feed(1, 2, 3), feed(4, 5), feed[int]())
+
+	var results []int
+	for v := range out {
+		results = append(results, v)
+	}
+
+	assert.ElementsMatch(t, []int{1, 2, 3, 4, 5}, results)
+}
+
+// TestCollect_NoInputs ensures the output is closed immediately when there are no inputs.
+func TestCollect_NoInputs(t *testing.T) {
+	_, ok := <-Collect[int](context.Background())
+	assert.False(t, ok)
+}
+
+// TestCollect_ContextCancel verifies that the output is closed when the context is canceled.
+func TestCollect_ContextCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	never := make(chan int)
+
+	out := Collect(ctx, never)
+	cancel()
+
+	select {
+	case _, ok := <-out:
+		assert.False(t, ok, "The output should be closed after cancellation")
+	case <-time.After(time.Second):
+		t.Fatal("Collect did not close its output after cancellation")
+	}
+}
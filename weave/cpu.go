@@ -0,0 +1,58 @@
+package weave
+
+import (
+	"context"
+	"runtime"
+)
+
+// CPUConcurrency returns runtime.GOMAXPROCS(0) times multiplier, a concurrency
+// limit that scales with the machine instead of being hardcoded.
+//
+// Use a multiplier of 1 for CPU-bound work, and a larger one for I/O-bound
+// tasks that spend most of their time waiting. A multiplier less than 1 is
+// treated as 1.
+//
+// Example:
+//
+//	w, err := weave.NewWeaver(ctx, weave.CPUConcurrency(4))
+func CPUConcurrency(multiplier int) int {
+	return runtime.GOMAXPROCS(0) * max(multiplier, 1)
+}
+
+// NewWeaverCPU creates a Weaver with one worker per available CPU, as
+// reported by runtime.GOMAXPROCS(0). It is shorthand for
+// NewWeaver(ctx, CPUConcurrency(1), opts...) for CPU-bound workloads.
+func NewWeaverCPU(ctx context.Context, opts ...Option) (*Weaver, error) {
+	return NewWeaver(ctx, CPUConcurrency(1), opts...)
+}
+
+// SailCPU behaves like Sail, but runs at most runtime.GOMAXPROCS(0) tasks at
+// once, which suits CPU-bound tasks that gain nothing from running more
+// goroutines than there are cores.
+//
+// The first error or panic cancels the context passed to the other tasks,
+// and tasks not yet started are skipped. If ctx is canceled and no task
+// failed, SailCPU returns ctx.Err(). Unlike Sail, SailCPU returns only once
+// every started task has finished.
+func SailCPU(ctx context.Context, tasks ...Task) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	w, err := NewWeaverCPU(ctx)
+	if err != nil {
+		return err
+	}
+	for _, task := range tasks {
+		// Add fails once a task has failed or ctx is canceled.
+		if w.Add(task) != nil {
+			break
+		}
+	}
+
+	// Wait reports task errors, but not the cancellation of ctx itself.
+	if err := w.Wait(); err != nil {
+		return err
+	}
+	return ctx.Err()
+}
@@ -0,0 +1,117 @@
+package weave
+
+import (
+	"context"
+	"errors"
+	"runtime"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestCPUConcurrency verifies that the limit scales with GOMAXPROCS and clamps the multiplier.
+func TestCPUConcurrency(t *testing.T) {
+	procs := runtime.GOMAXPROCS(0)
+
+	assert.Equal(t, procs, CPUConcurrency(1))
+	assert.Equal(t, 4*procs, CPUConcurrency(4))
+	assert.Equal(t, procs, CPUConcurrency(0), "A multiplier below 1 should be treated as 1")
+	assert.Equal(t, procs, CPUConcurrency(-3), "A multiplier below 1 should be treated as 1")
+}
+
+// TestSailCPU_BoundsConcurrency verifies that all tasks run and at most GOMAXPROCS run at once.
+func TestSailCPU_BoundsConcurrency(t *testing.T) {
+	defer runtime.GOMAXPROCS(runtime.GOMAXPROCS(2))
+
+	var running, peak, done atomic.Int32
+	tasks := make([]Task, 20)
+	for i := range tasks {
+		tasks[i] = func(ctx context.Context) error {
+			n := running.Add(1)
+			for {
+				p := peak.Load()
+				if n <= p || peak.CompareAndSwap(p, n) {
+					break
+				}
+			}
+			runtime.Gosched()
+			running.Add(-1)
+			done.Add(1)
+			return nil
+		}
+	}
+
+	assert.NoError(t, SailCPU(context.Background(), tasks...))
+	assert.Equal(t, int32(20), done.Load(), "Every task should run")
+	assert.LessOrEqual(t, peak.Load(), int32(2), "No more than GOMAXPROCS tasks should run at once")
+}
+
+// TestSailCPU_Error verifies that the first error is returned and cancels the remaining tasks.
+func TestSailCPU_Error(t *testing.T) {
+	defer runtime.GOMAXPROCS(runtime.GOMAXPROCS(1))
+
+	boom := errors.New("boom")
+	var ran atomic.Int32
+	tasks := []Task{func(ctx context.Context) error { return boom }}
+	for i := 0; i < 10; i++ {
+		tasks = append(tasks, func(ctx context.Context) error {
+			if ctx.Err() == nil {
+				ran.Add(1)
+			}
+			return nil
+		})
+	}
+
+	assert.ErrorIs(t, SailCPU(context.Background(), tasks...), boom)
+	assert.Less(t, ran.Load(), int32(10), "Tasks after the failure should be skipped or see a canceled context")
+}
+
+// TestSailCPU_Canceled verifies that an already-canceled context runs nothing.
+func TestSailCPU_Canceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	var ran atomic.Bool
+	err := SailCPU(ctx, func(ctx context.Context) error { ran.Store(true); return nil })
+
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.False(t, ran.Load(), "No task should run on a canceled context")
+}
+
+// TestNewWeaverCPU verifies that the Weaver runs tasks like one built with NewWeaver.
+func TestNewWeaverCPU(t *testing.T) {
+	w, err := NewWeaverCPU(context.Background())
+	assert.NoError(t, err)
+
+	var ran atomic.Int32
+	for i := 0; i < 5; i++ {
+		assert.NoError(t, w.Add(func(ctx context.Context) error { ran.Add(1); return nil }))
+	}
+	assert.NoError(t, w.Wait())
+	assert.Equal(t, int32(5), ran.Load())
+}
+
+// TestSailCPU_CanceledMidRun verifies that canceling ctx while tasks run returns ctx.Err(), like Sail.
+func TestSailCPU_CanceledMidRun(t *testing.T) {
+	defer runtime.GOMAXPROCS(runtime.GOMAXPROCS(1))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var ran atomic.Int32
+	tasks := []Task{func(ctx context.Context) error {
+		cancel()
+		<-ctx.Done()
+		return nil
+	}}
+	for i := 0; i < 10; i++ {
+		tasks = append(tasks, func(ctx context.Context) error {
+			ran.Add(1)
+			return nil
+		})
+	}
+
+	assert.ErrorIs(t, SailCPU(ctx, tasks...), context.Canceled)
+	assert.Less(t, ran.Load(), int32(10), "Tasks after the cancellation should be skipped")
+}
@@ -0,0 +1,34 @@
+package weave
+
+import "time"
+
+// logTaskStart records that worker id picked up a task.
+// Callers must check that a logger is configured.
+func (w *Weaver) logTaskStart(id int) {
+	w.opts.logger.Debug().
+		Int("worker", id).
+		Msg("Task started")
+}
+
+// logTaskDone records the outcome and duration of a task started at start.
+// It is a no-op when no logger is configured.
+func (w *Weaver) logTaskDone(id int, start time.Time, err error) {
+	logger := w.opts.logger
+	if logger == nil {
+		return
+	}
+
+	if err != nil {
+		logger.Error().
+			Err(err).
+			Int("worker", id).
+			Dur("duration_ms", time.Since(start)).
+			Msg("Task failed")
+		return
+	}
+
+	logger.Debug().
+		Int("worker", id).
+		Dur("duration_ms", time.Since(start)).
+		Msg("Task completed")
+}
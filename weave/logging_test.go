@@ -0,0 +1,65 @@
+package weave
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+)
+
+// syncBuffer is a bytes.Buffer safe for concurrent writes from workers.
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+// TestWeaver_WithLogger verifies that task lifecycle events are logged.
+func TestWeaver_WithLogger(t *testing.T) {
+	var out syncBuffer
+	logger := zerolog.New(&out).Level(zerolog.DebugLevel)
+
+	weaver, err := NewWeaver(context.Background(), 3, WithLogger(logger))
+	assert.NoError(t, err)
+
+	// Hold every task until all have started, so a failure cannot skip the others.
+	var started sync.WaitGroup
+	started.Add(3)
+	together := func(task Task) Task {
+		return func(ctx context.Context) error {
+			started.Done()
+			started.Wait()
+			return task(ctx)
+		}
+	}
+
+	assert.NoError(t, weaver.Add(together(func(ctx context.Context) error { return nil })))
+	assert.NoError(t, weaver.Add(together(func(ctx context.Context) error { return errors.New("disk full") })))
+	assert.NoError(t, weaver.Add(together(func(ctx context.Context) error { panic("kaboom") })))
+	weaver.Wait()
+
+	logs := out.String()
+	assert.Equal(t, 3, strings.Count(logs, `"message":"Task started"`))
+	assert.Equal(t, 1, strings.Count(logs, `"message":"Task completed"`))
+	assert.Equal(t, 2, strings.Count(logs, `"message":"Task failed"`))
+	assert.Contains(t, logs, `"error":"disk full"`)
+	assert.Contains(t, logs, `panic recovered: kaboom`)
+	assert.Contains(t, logs, `"duration_ms"`)
+	assert.Contains(t, logs, `"worker"`)
+}
@@ -0,0 +1,72 @@
+package weave
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// MapJoin applies fn to every element of in with at most limit calls running
+// concurrently, in a best-effort "report everything" mode.
+//
+// Unlike Sail and Weaver, a failing call does not cancel the others: every
+// element is processed, and the returned error is errors.Join of all
+// failures (nil if there were none). Panics are recovered and reported as
+// errors. The result slice is aligned with in; elements whose call failed
+// hold the zero value of R.
+//
+// If ctx is canceled, elements not yet started are skipped and ctx.Err() is
+// included in the joined error.
+//
+// Returns an error without calling fn if limit is less than or equal to zero.
+func MapJoin[T, R any](ctx context.Context, limit int, in []T, fn func(ctx context.Context, item T) (R, error)) ([]R, error) {
+	if limit <= 0 {
+		return nil, errors.New("weave: limit must be greater than 0")
+	}
+
+	results := make([]R, len(in))
+	errs := make([]error, len(in))
+	sem := make(chan struct{}, limit)
+
+	var wg sync.WaitGroup
+	var ctxErr error
+
+schedule:
+	for i, item := range in {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			ctxErr = ctx.Err()
+			break schedule
+		}
+		// select picks at random when both are ready; don't start new work
+		// after cancellation.
+		if err := ctx.Err(); err != nil {
+			<-sem
+			ctxErr = err
+			break
+		}
+
+		wg.Add(1)
+		go func(i int, item T) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			defer func() {
+				if r := recover(); r != nil {
+					errs[i] = &PanicError{value: r}
+				}
+			}()
+
+			result, err := fn(ctx, item)
+			if err != nil {
+				errs[i] = err
+				return
+			}
+			results[i] = result
+		}(i, item)
+	}
+
+	wg.Wait()
+
+	return results, errors.Join(append(errs, ctxErr)...)
+}
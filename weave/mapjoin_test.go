@@ -0,0 +1,96 @@
+package weave
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestMapJoin_Success verifies that results are aligned with the input.
+func TestMapJoin_Success(t *testing.T) {
+	results, err := MapJoin(context.Background(), 2, []int{1, 2, 3}, func(ctx context.Context, n int) (string, error) {
+		return strconv.Itoa(n * 10), nil
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"10", "20", "30"}, results)
+}
+
+// TestMapJoin_CollectsAllErrors ensures failures do not cancel other calls and are all reported.
+func TestMapJoin_CollectsAllErrors(t *testing.T) {
+	errOdd := errors.New("odd")
+	var calls int32
+
+	results, err := MapJoin(context.Background(), 3, []int{1, 2, 3, 4, 5}, func(ctx context.Context, n int) (int, error) {
+		atomic.AddInt32(&calls, 1)
+		if n == 5 {
+			panic("five")
+		}
+		if n%2 == 1 {
+			return 0, errOdd
+		}
+		return n, nil
+	})
+
+	assert.Equal(t, int32(5), atomic.LoadInt32(&calls), "Every element should be processed")
+	assert.Equal(t, []int{0, 2, 0, 4, 0}, results, "Successful results should be kept")
+	assert.ErrorIs(t, err, errOdd)
+	assert.ErrorContains(t, err, "panic recovered: five")
+
+	var joined interface{ Unwrap() []error }
+	assert.True(t, errors.As(err, &joined))
+	assert.Len(t, joined.Unwrap(), 3, "All three failures should be joined")
+}
+
+// TestMapJoin_InvalidLimit ensures MapJoin validates the concurrency limit.
+func TestMapJoin_InvalidLimit(t *testing.T) {
+	_, err := MapJoin(context.Background(), 0, []int{1}, func(ctx context.Context, n int) (int, error) { return n, nil })
+	assert.ErrorContains(t, err, "limit must be greater than 0")
+}
+
+// TestMapJoin_ContextCancel verifies that a canceled context skips remaining elements.
+func TestMapJoin_ContextCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	var calls int32
+	_, err := MapJoin(ctx, 1, []int{1, 2, 3}, func(ctx context.Context, n int) (int, error) {
+		atomic.AddInt32(&calls, 1)
+		return n, nil
+	})
+
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Less(t, atomic.LoadInt32(&calls), int32(3))
+}
+
+// TestMapJoin_FailedElementsAreZero verifies that a value returned alongside an error is discarded.
+func TestMapJoin_FailedElementsAreZero(t *testing.T) {
+	results, err := MapJoin(context.Background(), 2, []int{1, 2, 3}, func(ctx context.Context, n int) (string, error) {
+		if n == 2 {
+			return "partial", errors.New("failed")
+		}
+		return strconv.Itoa(n), nil
+	})
+
+	assert.Error(t, err)
+	assert.Equal(t, []string{"1", "", "3"}, results)
+}
+
+// TestMapJoin_NoStartAfterCancel verifies that no element starts once ctx is canceled, even with free slots.
+func TestMapJoin_NoStartAfterCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	var started atomic.Int32
+	_, err := MapJoin(ctx, 100, make([]int, 100), func(ctx context.Context, n int) (int, error) {
+		started.Add(1)
+		return n, nil
+	})
+
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Zero(t, started.Load(), "No element should start on a canceled context")
+}
@@ -0,0 +1,74 @@
+package weave
+
+import "github.com/rs/zerolog"
+
+// options holds the optional behaviors of a Weaver.
+type options struct {
+	repanic bool // Re-raise the first task panic from Wait instead of returning it as an error.
+	ordered bool // ResultWeaver returns results in submission order.
+	collect bool // Keep running after task errors and report them all from Wait.
+	onError func(workerID int, err error)
+	logger  *zerolog.Logger // Per-task lifecycle logging; nil disables it.
+}
+
+// Option configures optional Weaver behavior. Pass options to NewWeaver.
+type Option func(*options)
+
+// WithRepanic makes the Weaver re-raise the first task panic on the goroutine
+// calling Wait (or Close), after all workers have stopped.
+//
+// Use it when policy dictates that a panicking task should crash the process
+// so a supervisor can restart it. By default, panics are recovered and
+// returned from Wait as errors.
+func WithRepanic() Option {
+	return func(o *options) {
+		o.repanic = true
+	}
+}
+
+// WithOrderedResults makes a ResultWeaver return its results in the order
+// the tasks were added, rather than the order in which they completed.
+//
+// Ordering requires buffering and sorting every result until Wait, so use it
+// only when the order is semantically meaningful. It has no effect on a plain
+// Weaver.
+func WithOrderedResults() Option {
+	return func(o *options) {
+		o.ordered = true
+	}
+}
+
+// WithContinueOnError makes the Weaver run every task even after some fail,
+// instead of canceling the remaining work on the first error.
+//
+// Wait then returns errors.Join of every task error (including recovered
+// panics), in the order they occurred, or nil if all tasks succeeded. Err
+// still reports the first one. Use it for bulk jobs where each task is
+// independent and all failures should be reported together.
+func WithContinueOnError() Option {
+	return func(o *options) {
+		o.collect = true
+	}
+}
+
+// WithOnError registers fn to be called with the worker index and the error
+// every time a task fails or panics, not just for the first failure.
+//
+// fn runs synchronously on the worker goroutine, so it should be fast and
+// must be safe for concurrent use.
+func WithOnError(fn func(workerID int, err error)) Option {
+	return func(o *options) {
+		o.onError = fn
+	}
+}
+
+// WithLogger enables structured per-task lifecycle logging.
+//
+// Each task logs a debug event when it starts and when it completes, with the
+// worker index and duration; failures and panics are logged at error level
+// with the error attached. Without this option no logging code runs at all.
+func WithLogger(logger zerolog.Logger) Option {
+	return func(o *options) {
+		o.logger = &logger
+	}
+}
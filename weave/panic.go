@@ -0,0 +1,35 @@
+package weave
+
+import "fmt"
+
+// PanicError is the error reported in place of a task that panicked.
+//
+// Sail, SailIndexed, Weaver, and the other helpers in this package return
+// panics as *PanicError, so the original value can be recovered with
+// errors.As:
+//
+//	var pe *weave.PanicError
+//	if errors.As(err, &pe) {
+//		log.Printf("task panicked with %T: %v", pe.Value(), pe.Value())
+//	}
+type PanicError struct {
+	value  any    // The value passed to panic.
+	prefix string // Optional context for Error, e.g. "worker 3 ".
+}
+
+// Value returns the raw value the task panicked with, unformatted.
+func (e *PanicError) Value() any {
+	return e.value
+}
+
+// Error implements the error interface.
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("%spanic recovered: %v", e.prefix, e.value)
+}
+
+// Unwrap returns the panic value if it is an error, so that errors.Is and
+// errors.As can see through the panic to the error that was thrown.
+func (e *PanicError) Unwrap() error {
+	err, _ := e.value.(error)
+	return err
+}
@@ -0,0 +1,50 @@
+package weave
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestPanicError_Error verifies that PanicError formats like the errors Sail has always returned for panics.
+func TestPanicError_Error(t *testing.T) {
+	err := &PanicError{value: "boom"}
+
+	assert.EqualError(t, err, "panic recovered: boom")
+}
+
+// TestPanicError_ValueFromSailAndWeaver verifies that the raw panic value is reachable with errors.As.
+func TestPanicError_ValueFromSailAndWeaver(t *testing.T) {
+	type codedPanic struct{ code int }
+
+	err := Sail(context.Background(), func(ctx context.Context) error {
+		panic(codedPanic{code: 42})
+	})
+
+	var panicErr *PanicError
+	if assert.ErrorAs(t, err, &panicErr, "Sail should return a *PanicError") {
+		assert.Equal(t, codedPanic{code: 42}, panicErr.Value(), "Value should return the unformatted panic value")
+	}
+
+	weaver, err := NewWeaver(context.Background(), 1)
+	assert.NoError(t, err)
+	assert.NoError(t, weaver.Add(func(ctx context.Context) error { panic(codedPanic{code: 7}) }))
+
+	err = weaver.Wait()
+	if assert.ErrorAs(t, err, &panicErr, "Weaver.Wait should return a *PanicError") {
+		assert.Equal(t, codedPanic{code: 7}, panicErr.Value())
+	}
+	assert.ErrorContains(t, err, "worker 0 panic recovered")
+}
+
+// TestPanicError_Unwrap verifies that errors thrown via panic remain matchable with errors.Is.
+func TestPanicError_Unwrap(t *testing.T) {
+	sentinel := errors.New("thrown")
+
+	err := Sail(context.Background(), func(ctx context.Context) error { panic(sentinel) })
+
+	assert.ErrorIs(t, err, sentinel)
+	assert.NoError(t, (&PanicError{value: "not an error"}).Unwrap())
+}
@@ -0,0 +1,171 @@
+package weave
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+)
+
+// stageFunc wires one pipeline stage. It receives the previous stage's output
+// channel (boxed in an any) and returns its own output channel, registering
+// every goroutine it starts with wg and reporting failures through fail.
+type stageFunc func(ctx context.Context, wg *sync.WaitGroup, in any, fail func(error)) any
+
+// Pipeline is a chain of concurrent processing stages connected by channels.
+// T is the type fed into the first stage and R the type produced by the last.
+//
+// Pipelines are built with NewPipeline and Stage, and are immutable: adding a
+// stage returns a new Pipeline, so a partially built pipeline can be reused.
+//
+// Example:
+//
+//	p := weave.NewPipeline[string]()
+//	parsed := weave.Stage(p, 4, parseRecord)         // string -> Record
+//	enriched := weave.Stage(parsed, 8, enrichRecord) // Record -> Enriched
+//
+//	out, wait := enriched.Run(ctx, lines)
+//	for rec := range out {
+//		write(rec)
+//	}
+//	if err := wait(); err != nil {
+//		return err
+//	}
+type Pipeline[T, R any] struct {
+	stages []stageFunc
+	err    error // First configuration error, reported by Run.
+}
+
+// NewPipeline returns an empty Pipeline accepting values of type T.
+// Running it without adding stages passes the input through unchanged.
+func NewPipeline[T any]() *Pipeline[T, T] {
+	return &Pipeline[T, T]{}
+}
+
+// Stage returns a new Pipeline that extends p with a stage running fn on up
+// to concurrency goroutines. Values are processed in arrival order but, with
+// concurrency greater than one, may be emitted out of order.
+//
+// If fn returns an error or panics, the whole pipeline is canceled and that
+// error is reported by Run's wait function. A concurrency less than or equal
+// to zero is reported the same way when the pipeline is run.
+func Stage[T, In, Out any](p *Pipeline[T, In], concurrency int, fn func(ctx context.Context, in In) (Out, error)) *Pipeline[T, Out] {
+	next := &Pipeline[T, Out]{err: p.err}
+	if concurrency <= 0 && next.err == nil {
+		next.err = errors.New("weave: concurrency must be greater than 0")
+	}
+
+	next.stages = append(slices.Clip(p.stages), func(ctx context.Context, wg *sync.WaitGroup, in any, fail func(error)) any {
+		inCh := in.(<-chan In)
+		out := make(chan Out)
+
+		var stageWG sync.WaitGroup
+		stageWG.Add(concurrency)
+		wg.Add(concurrency + 1)
+
+		for i := 0; i < concurrency; i++ {
+			go func() {
+				defer wg.Done()
+				defer stageWG.Done()
+				for {
+					var v In
+					var ok bool
+					select {
+					case v, ok = <-inCh:
+						if !ok {
+							return
+						}
+					case <-ctx.Done():
+						return
+					}
+
+					result, err := callStage(ctx, fn, v)
+					if err != nil {
+						fail(err)
+						return
+					}
+
+					select {
+					case out <- result:
+					case <-ctx.Done():
+						return
+					}
+				}
+			}()
+		}
+
+		// Close the output once every worker of this stage has exited.
+		go func() {
+			defer wg.Done()
+			stageWG.Wait()
+			close(out)
+		}()
+
+		return (<-chan Out)(out)
+	})
+
+	return next
+}
+
+// callStage invokes fn, converting a panic into an error.
+func callStage[In, Out any](ctx context.Context, fn func(context.Context, In) (Out, error), v In) (out Out, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = &PanicError{value: r}
+		}
+	}()
+	return fn(ctx, v)
+}
+
+// Run starts the pipeline, feeding it from input, and returns the output
+// channel of the last stage along with a wait function.
+//
+// The output channel is closed once input is closed and fully processed, or
+// once the pipeline is canceled. The caller must drain it (or cancel ctx) to
+// let the stages finish. wait blocks until every stage goroutine has exited
+// and returns the first stage error, ctx.Err() if the parent context was
+// canceled, or nil.
+func (p *Pipeline[T, R]) Run(ctx context.Context, input <-chan T) (<-chan R, func() error) {
+	if p.err != nil {
+		out := make(chan R)
+		close(out)
+		return out, func() error { return p.err }
+	}
+
+	parent := ctx
+	ctx, cancel := context.WithCancel(ctx)
+
+	var (
+		wg       sync.WaitGroup
+		once     sync.Once
+		firstErr error
+	)
+	fail := func(err error) {
+		once.Do(func() {
+			firstErr = err
+			cancel()
+		})
+	}
+
+	var cur any = input
+	for _, stage := range p.stages {
+		cur = stage(ctx, &wg, cur, fail)
+	}
+
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		cancel()
+		close(done)
+	}()
+
+	wait := func() error {
+		<-done
+		if firstErr != nil {
+			return firstErr
+		}
+		return parent.Err()
+	}
+
+	return cur.(<-chan R), wait
+}
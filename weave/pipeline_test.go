@@ -0,0 +1,100 @@
+package weave
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// feed returns a closed channel pre-loaded with values.
+func feed[T any](values ...T) <-chan T {
+	ch := make(chan T, len(values))
+	for _, v := range values {
+		ch <- v
+	}
+	close(ch)
+	return ch
+}
+
+// TestPipeline_Success verifies that typed stages are chained and every value flows through.
+func TestPipeline_Success(t *testing.T) {
+	p := NewPipeline[string]()
+	parsed := Stage(p, 2, func(ctx context.Context, s string) (int, error) { return strconv.Atoi(s) })
+	doubled := Stage(parsed, 3, func(ctx context.Context, n int) (int, error) { return n * 2, nil })
+
+	out, wait := doubled.Run(context.Background(), feed("1", "2", "3", "4"))
+
+	var results []int
+	for v := range out {
+		results = append(results, v)
+	}
+	sort.Ints(results)
+
+	assert.NoError(t, wait())
+	assert.Equal(t, []int{2, 4, 6, 8}, results)
+}
+
+// TestPipeline_Error ensures the first stage error cancels the whole pipeline.
+func TestPipeline_Error(t *testing.T) {
+	p := NewPipeline[string]()
+	parsed := Stage(p, 1, func(ctx context.Context, s string) (int, error) { return strconv.Atoi(s) })
+
+	input := make(chan string)
+	go func() {
+		defer close(input)
+		for _, s := range []string{"1", "oops", "3"} {
+			select {
+			case input <- s:
+			case <-time.After(100 * time.Millisecond):
+				return
+			}
+		}
+	}()
+
+	out, wait := parsed.Run(context.Background(), input)
+	for range out {
+	}
+
+	var numErr *strconv.NumError
+	assert.ErrorAs(t, wait(), &numErr, "The stage error should be reported by wait")
+}
+
+// TestPipeline_Panic verifies that stage panics are recovered and reported.
+func TestPipeline_Panic(t *testing.T) {
+	p := Stage(NewPipeline[int](), 1, func(ctx context.Context, n int) (int, error) { panic("stage panic") })
+
+	out, wait := p.Run(context.Background(), feed(1))
+	for range out {
+	}
+
+	assert.ErrorContains(t, wait(), "panic recovered: stage panic")
+}
+
+// TestPipeline_InvalidConcurrency ensures configuration errors are reported by Run.
+func TestPipeline_InvalidConcurrency(t *testing.T) {
+	p := Stage(NewPipeline[int](), 0, func(ctx context.Context, n int) (int, error) { return n, nil })
+
+	out, wait := p.Run(context.Background(), feed(1))
+	_, ok := <-out
+
+	assert.False(t, ok, "The output channel should be closed")
+	assert.ErrorContains(t, wait(), "concurrency must be greater than 0")
+}
+
+// TestPipeline_ContextCancel verifies that canceling the parent context stops the pipeline.
+func TestPipeline_ContextCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	p := Stage(NewPipeline[int](), 1, func(ctx context.Context, n int) (int, error) { return n, nil })
+
+	out, wait := p.Run(ctx, make(chan int))
+	cancel()
+	for range out {
+	}
+
+	assert.True(t, errors.Is(wait(), context.Canceled))
+}
@@ -0,0 +1,87 @@
+package weave
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"sync/atomic"
+)
+
+// ResultTask is a unit of concurrent work that produces a value.
+type ResultTask[T any] func(ctx context.Context) (T, error)
+
+// ResultWeaver is a Weaver that collects the value produced by each task.
+//
+// It shares the Weaver's bounded concurrency, panic recovery, and lifecycle
+// semantics. By default Wait returns results in completion order; pass
+// WithOrderedResults to get them in submission order instead.
+type ResultWeaver[T any] struct {
+	weaver *Weaver
+	seq    atomic.Uint64
+
+	mu      sync.Mutex
+	results []indexedResult[T]
+}
+
+// indexedResult pairs a task result with its submission index.
+type indexedResult[T any] struct {
+	seq   uint64
+	value T
+}
+
+// NewResultWeaver creates a ResultWeaver with a fixed concurrency limit.
+// Options are the same as for NewWeaver.
+//
+// If concurrency is less than or equal to zero, NewResultWeaver returns an error.
+func NewResultWeaver[T any](ctx context.Context, concurrency int, opts ...Option) (*ResultWeaver[T], error) {
+	w, err := NewWeaver(ctx, concurrency, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &ResultWeaver[T]{weaver: w}, nil
+}
+
+// Add submits a task for execution. Its result is collected only if the
+// task returns a nil error. Add fails under the same conditions as Weaver.Add.
+func (rw *ResultWeaver[T]) Add(task ResultTask[T]) error {
+	seq := rw.seq.Add(1)
+	return rw.weaver.Add(func(ctx context.Context) error {
+		value, err := task(ctx)
+		if err != nil {
+			return err
+		}
+
+		rw.mu.Lock()
+		rw.results = append(rw.results, indexedResult[T]{seq: seq, value: value})
+		rw.mu.Unlock()
+		return nil
+	})
+}
+
+// Wait blocks until all tasks have completed and returns the collected
+// results along with the first task error, as Weaver.Wait does.
+// Results of successful tasks are returned even when an error occurred.
+func (rw *ResultWeaver[T]) Wait() ([]T, error) {
+	err := rw.weaver.Wait()
+
+	rw.mu.Lock()
+	defer rw.mu.Unlock()
+
+	if rw.weaver.opts.ordered {
+		slices.SortFunc(rw.results, func(a, b indexedResult[T]) int {
+			switch {
+			case a.seq < b.seq:
+				return -1
+			case a.seq > b.seq:
+				return 1
+			}
+			return 0
+		})
+	}
+
+	values := make([]T, len(rw.results))
+	for i, r := range rw.results {
+		values[i] = r.value
+	}
+	return values, err
+}
@@ -0,0 +1,66 @@
+package weave
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestResultWeaver_Collects verifies that every successful task result is collected.
+func TestResultWeaver_Collects(t *testing.T) {
+	rw, err := NewResultWeaver[int](context.Background(), 4)
+	assert.NoError(t, err)
+
+	for i := 0; i < 10; i++ {
+		n := i
+		assert.NoError(t, rw.Add(func(ctx context.Context) (int, error) { return n, nil }))
+	}
+
+	results, err := rw.Wait()
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}, results)
+}
+
+// TestResultWeaver_Ordered ensures WithOrderedResults returns results in submission order.
+func TestResultWeaver_Ordered(t *testing.T) {
+	rw, err := NewResultWeaver[int](context.Background(), 4, WithOrderedResults())
+	assert.NoError(t, err)
+
+	for i := 0; i < 8; i++ {
+		n := i
+		assert.NoError(t, rw.Add(func(ctx context.Context) (int, error) {
+			// Later tasks finish first.
+			time.Sleep(time.Duration(8-n) * time.Millisecond)
+			return n, nil
+		}))
+	}
+
+	results, err := rw.Wait()
+	assert.NoError(t, err)
+	assert.Equal(t, []int{0, 1, 2, 3, 4, 5, 6, 7}, results)
+}
+
+// TestResultWeaver_Error ensures failed tasks are excluded and the first error is returned.
+func TestResultWeaver_Error(t *testing.T) {
+	rw, err := NewResultWeaver[string](context.Background(), 1, WithOrderedResults())
+	assert.NoError(t, err)
+
+	expectedErr := errors.New("task failed")
+	assert.NoError(t, rw.Add(func(ctx context.Context) (string, error) { return "a", nil }))
+	assert.NoError(t, rw.Add(func(ctx context.Context) (string, error) { return "b", nil }))
+	assert.NoError(t, rw.Add(func(ctx context.Context) (string, error) { return "", expectedErr }))
+
+	results, err := rw.Wait()
+	assert.Equal(t, expectedErr, err)
+	assert.Equal(t, []string{"a", "b"}, results)
+}
+
+// TestResultWeaver_InvalidConcurrency ensures NewResultWeaver validates concurrency.
+func TestResultWeaver_InvalidConcurrency(t *testing.T) {
+	rw, err := NewResultWeaver[int](context.Background(), 0)
+	assert.Error(t, err)
+	assert.Nil(t, rw)
+}
@@ -4,8 +4,8 @@ package weave
 
 import (
 	"context"
-	"fmt"
 	"sync"
+	"time"
 )
 
 // Task represents a unit of concurrent work that accepts a context
@@ -18,13 +18,27 @@ type Task func(ctx context.Context) error
 //
 // Sail guarantees the following:
 //   - Each task is executed in its own goroutine.
-//   - If any task returns a non-nil error or panics, Sail returns that error immediately.
+//   - If any task returns a non-nil error or panics, Sail returns that error immediately
+//     and cancels the context passed to the other tasks, so cooperative tasks
+//     can stop early. Tasks not yet started are skipped.
+//   - If the provided context is already canceled, Sail returns ctx.Err()
+//     immediately without launching any goroutines.
 //   - If the provided context is canceled, Sail stops scheduling new tasks
 //     and returns ctx.Err().
-//   - All panics are safely recovered and returned as formatted errors.
+//   - All panics are safely recovered and returned as *PanicError.
 //
 // The function blocks until all tasks have completed, an error occurs, or the context is canceled.
 func Sail(ctx context.Context, tasks ...Task) error {
+	// Fast-path: nothing useful can run on an already-canceled context.
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	// Tasks share a derived context, canceled on the first failure so peers
+	// stop early. External cancellation still flows through from ctx.
+	taskCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
 	var wg sync.WaitGroup
 	wg.Add(len(tasks))
 
@@ -34,12 +48,13 @@ func Sail(ctx context.Context, tasks ...Task) error {
 	sendErr := func(err error) {
 		once.Do(func() {
 			errChan <- err
+			cancel()
 		})
 	}
 
 	for _, task := range tasks {
-		// Skip task if context is already canceled.
-		if ctx.Err() != nil {
+		// Skip task if the context is canceled or a sibling already failed.
+		if taskCtx.Err() != nil {
 			wg.Done()
 			continue
 		}
@@ -48,11 +63,11 @@ func Sail(ctx context.Context, tasks ...Task) error {
 			defer wg.Done()
 			defer func() {
 				if r := recover(); r != nil {
-					sendErr(fmt.Errorf("panic recovered: %v", r))
+					sendErr(&PanicError{value: r})
 				}
 			}()
 
-			if err := t(ctx); err != nil {
+			if err := t(taskCtx); err != nil {
 				sendErr(err)
 			}
 		}(task)
@@ -71,3 +86,102 @@ func Sail(ctx context.Context, tasks ...Task) error {
 		return ctx.Err()
 	}
 }
+
+// SailIndexed runs all tasks concurrently and reports the outcome of each one
+// by position, for diagnostics that need to know exactly which tasks failed.
+//
+// Unlike Sail, a failing task does not stop or cancel the others: SailIndexed
+// waits for every task and returns a slice of length len(tasks) whose element
+// i is the error returned by tasks[i], or nil if it succeeded. A panicking
+// task is recovered and reported as a *PanicError. Tasks that were not started
+// because ctx was already canceled report ctx.Err().
+func SailIndexed(ctx context.Context, tasks ...Task) []error {
+	errs := make([]error, len(tasks))
+
+	var wg sync.WaitGroup
+	for i, task := range tasks {
+		if err := ctx.Err(); err != nil {
+			errs[i] = err
+			continue
+		}
+
+		wg.Add(1)
+		go func(i int, t Task) {
+			defer wg.Done()
+			defer func() {
+				if r := recover(); r != nil {
+					errs[i] = &PanicError{value: r}
+				}
+			}()
+
+			errs[i] = t(ctx)
+		}(i, task)
+	}
+
+	wg.Wait()
+	return errs
+}
+
+// SailBest runs all tasks concurrently and returns the values of those that
+// succeed within d, for best-effort aggregation such as querying several
+// sources and using whichever answer in time.
+//
+// SailBest returns as soon as every task has finished or d has elapsed,
+// whichever comes first, and then cancels the context passed to the tasks
+// still running so they can stop. Values are returned in completion order;
+// the slice may be empty. Task errors are ignored, and panics are recovered
+// and treated like errors, so a misbehaving task cannot crash the caller.
+// If ctx is canceled first, the values gathered so far are returned.
+//
+// Example:
+//
+//	quotes := weave.SailBest(ctx, 200*time.Millisecond, quoteFromA, quoteFromB, quoteFromC)
+func SailBest[T any](ctx context.Context, d time.Duration, tasks ...ResultTask[T]) []T {
+	ctx, cancel := context.WithTimeout(ctx, d)
+	defer cancel()
+
+	// Buffered so that laggards finishing after SailBest returns never block.
+	values := make(chan T, len(tasks))
+
+	var wg sync.WaitGroup
+	for _, task := range tasks {
+		if ctx.Err() != nil {
+			break
+		}
+
+		wg.Add(1)
+		go func(t ResultTask[T]) {
+			defer wg.Done()
+			defer func() {
+				// A panic only loses this task's value.
+				_ = recover()
+			}()
+
+			if v, err := t(ctx); err == nil {
+				values <- v
+			}
+		}(task)
+	}
+
+	allDone := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(allDone)
+	}()
+
+	results := make([]T, 0, len(tasks))
+	for {
+		select {
+		case v := <-values:
+			results = append(results, v)
+		case <-allDone:
+			// Every task has finished, so the remaining values are buffered.
+			for len(values) > 0 {
+				results = append(results, <-values)
+			}
+			return results
+		case <-ctx.Done():
+			return results
+		}
+	}
+}
@@ -0,0 +1,79 @@
+package weave
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"time"
+)
+
+// GracefulShutdown runs every closer concurrently, e.g. to stop an HTTP
+// server, a database pool, and queue consumers in parallel, and waits for
+// them for at most timeout.
+//
+// Each closer receives a context that is canceled when the grace period (or
+// ctx) ends. Closers run to completion independently (see SailIndexed), so
+// one failure does not cut the others short. The result is errors.Join of
+// every failure, each prefixed with the closer's position, e.g.
+// "weave: closer 1: connection reset"; panics are reported as *PanicError.
+//
+// If the grace period ends first, GracefulShutdown returns without waiting
+// further: closers that have not finished are reported with the context's
+// error. A timeout less than or equal to zero disables the extra deadline,
+// leaving only ctx in control.
+//
+// Example:
+//
+//	err := weave.GracefulShutdown(context.Background(), 10*time.Second,
+//		srv.Shutdown,
+//		func(ctx context.Context) error { return db.Close() },
+//		consumer.Stop,
+//	)
+func GracefulShutdown(ctx context.Context, timeout time.Duration, closers ...func(context.Context) error) error {
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	errs := make([]error, len(closers))
+	finished := make([]atomic.Bool, len(closers))
+
+	tasks := make([]Task, len(closers))
+	for i, closer := range closers {
+		tasks[i] = func(ctx context.Context) (err error) {
+			defer func() {
+				if r := recover(); r != nil {
+					err = &PanicError{value: r}
+				}
+				errs[i] = err
+				finished[i].Store(true)
+			}()
+			return closer(ctx)
+		}
+	}
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		SailIndexed(ctx, tasks...)
+	}()
+
+	select {
+	case <-done:
+	case <-ctx.Done():
+	}
+
+	var failures []error
+	for i := range closers {
+		err := ctx.Err()
+		if finished[i].Load() {
+			err = errs[i]
+		}
+		if err != nil {
+			failures = append(failures, fmt.Errorf("weave: closer %d: %w", i, err))
+		}
+	}
+	return errors.Join(failures...)
+}
@@ -0,0 +1,67 @@
+package weave
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestGracefulShutdown_Success verifies that all closers run concurrently and nil is returned.
+func TestGracefulShutdown_Success(t *testing.T) {
+	var closed int32
+	closer := func(ctx context.Context) error {
+		time.Sleep(30 * time.Millisecond)
+		atomic.AddInt32(&closed, 1)
+		return nil
+	}
+
+	start := time.Now()
+	err := GracefulShutdown(context.Background(), time.Second, closer, closer, closer)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int32(3), atomic.LoadInt32(&closed))
+	assert.Less(t, time.Since(start), 80*time.Millisecond, "Closers should run concurrently")
+}
+
+// TestGracefulShutdown_ReportsFailingClosers verifies that every failure is joined and labeled by position.
+func TestGracefulShutdown_ReportsFailingClosers(t *testing.T) {
+	dbErr := errors.New("db close failed")
+
+	err := GracefulShutdown(context.Background(), time.Second,
+		func(ctx context.Context) error { return nil },
+		func(ctx context.Context) error { return dbErr },
+		func(ctx context.Context) error { panic("queue exploded") },
+	)
+
+	assert.ErrorIs(t, err, dbErr)
+	assert.ErrorContains(t, err, "weave: closer 1: db close failed")
+	assert.ErrorContains(t, err, "weave: closer 2: panic recovered: queue exploded")
+	assert.NotContains(t, err.Error(), "closer 0")
+
+	var panicErr *PanicError
+	assert.ErrorAs(t, err, &panicErr)
+}
+
+// TestGracefulShutdown_Timeout verifies that hung closers are abandoned after the grace period.
+func TestGracefulShutdown_Timeout(t *testing.T) {
+	hang := make(chan struct{})
+	defer close(hang)
+
+	start := time.Now()
+	err := GracefulShutdown(context.Background(), 30*time.Millisecond,
+		func(ctx context.Context) error { return nil },
+		func(ctx context.Context) error {
+			<-hang // ignores ctx
+			return nil
+		},
+	)
+
+	assert.Less(t, time.Since(start), 500*time.Millisecond, "GracefulShutdown should not wait past the deadline")
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.ErrorContains(t, err, "weave: closer 1:")
+	assert.NotContains(t, err.Error(), "closer 0")
+}
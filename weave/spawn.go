@@ -0,0 +1,87 @@
+package weave
+
+import "errors"
+
+// Spawn submits a task from within a running task, e.g. when a crawler
+// discovers new pages to visit.
+//
+// Calling Add from a task can deadlock: once the queue is full and every
+// worker is itself blocked in Add, nobody is left to drain the queue. Spawn
+// never blocks. The task is placed on an unbounded backlog that a separate
+// goroutine feeds into the queue, so concurrency stays bounded by the
+// Weaver's limit.
+//
+// Tasks spawned before the spawning task returns are part of the same
+// batch: Close and Wait do not return until they, and anything they spawn in
+// turn, have finished. Spawn returns an error only if the Weaver has already
+// finished draining. Spawned tasks are dropped if the Weaver's context is
+// canceled before they start.
+func (w *Weaver) Spawn(task Task) error {
+	// While any task is outstanding, Close cannot finish draining, so the
+	// backlog will still be consumed. If nothing was outstanding and Close
+	// has begun, the queue may already be closed.
+	if w.active.Add(1) == 1 && w.closing.Load() {
+		w.taskDone()
+		return errors.New("weave: weaver is closed")
+	}
+
+	w.spawnMu.Lock()
+	w.spawnBacklog = append(w.spawnBacklog, task)
+	startFeeder := !w.feeding
+	w.feeding = true
+	w.spawnMu.Unlock()
+
+	if startFeeder {
+		go w.feed()
+	}
+	return nil
+}
+
+// feed moves spawned tasks from the backlog into the task queue until the
+// backlog is empty. Blocking here instead of in the spawning task is what
+// keeps Spawn deadlock-free.
+func (w *Weaver) feed() {
+	done := (*w.taskCtx.Load()).Done()
+
+	for {
+		w.spawnMu.Lock()
+		if len(w.spawnBacklog) == 0 {
+			w.feeding = false
+			w.spawnMu.Unlock()
+			return
+		}
+		task := w.spawnBacklog[0]
+		w.spawnBacklog[0] = nil
+		w.spawnBacklog = w.spawnBacklog[1:]
+		w.spawnMu.Unlock()
+
+		select {
+		case <-done:
+			// Nothing will run anymore; drop the task.
+			w.taskDone()
+		default:
+			if !w.enqueueSpawned(task, done) {
+				w.taskDone()
+			}
+		}
+	}
+}
+
+// enqueueSpawned sends task to the queue, giving up if done is closed.
+// It reports whether the task was enqueued.
+func (w *Weaver) enqueueSpawned(task Task, done <-chan struct{}) (ok bool) {
+	// Close stops waiting for outstanding tasks once the context is canceled,
+	// so the queue may be closed under us in that case.
+	defer func() {
+		if recover() != nil {
+			ok = false
+		}
+	}()
+
+	select {
+	case w.taskQueue <- task:
+		return true
+	case <-done:
+		return false
+	}
+}
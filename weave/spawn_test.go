@@ -0,0 +1,120 @@
+package weave
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestWeaver_Spawn_Tree verifies that tasks can recursively spawn a tree of subtasks
+// without deadlocking, even when the tree is far wider than the concurrency limit.
+func TestWeaver_Spawn_Tree(t *testing.T) {
+	const depth, fanOut = 5, 4
+
+	w, err := NewWeaver(context.Background(), 2)
+	assert.NoError(t, err)
+
+	var executed atomic.Int64
+	var node func(level int) Task
+	node = func(level int) Task {
+		return func(ctx context.Context) error {
+			executed.Add(1)
+			if level == depth {
+				return nil
+			}
+			for i := 0; i < fanOut; i++ {
+				if err := w.Spawn(node(level + 1)); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+	}
+
+	assert.NoError(t, w.Add(node(0)))
+
+	done := make(chan error, 1)
+	go func() { done <- w.Wait() }()
+
+	select {
+	case err := <-done:
+		assert.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("Wait did not return; Spawn deadlocked")
+	}
+
+	// 1 + 4 + 16 + 64 + 256 + 1024 nodes.
+	want := int64(0)
+	for level, n := 0, int64(1); level <= depth; level, n = level+1, n*fanOut {
+		want += n
+	}
+	assert.Equal(t, want, executed.Load())
+}
+
+// TestWeaver_Spawn_Canceled ensures Wait returns once the context is canceled, even
+// with spawned tasks still in the backlog.
+func TestWeaver_Spawn_Canceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	w, err := NewWeaver(ctx, 1)
+	assert.NoError(t, err)
+
+	boom := assert.AnError
+	assert.NoError(t, w.Add(func(ctx context.Context) error {
+		for i := 0; i < 100; i++ {
+			_ = w.Spawn(func(ctx context.Context) error { return boom })
+		}
+		cancel()
+		return nil
+	}))
+
+	done := make(chan error, 1)
+	go func() { done <- w.Wait() }()
+
+	select {
+	case err := <-done:
+		if err != nil {
+			assert.ErrorIs(t, err, boom)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Wait did not return after cancellation")
+	}
+}
+
+// TestWeaver_Spawn_Closed ensures Spawn rejects tasks once the Weaver has drained.
+func TestWeaver_Spawn_Closed(t *testing.T) {
+	w, err := NewWeaver(context.Background(), 1)
+	assert.NoError(t, err)
+	assert.NoError(t, w.Wait())
+
+	err = w.Spawn(func(ctx context.Context) error { return nil })
+	assert.Error(t, err)
+}
+
+// TestWeaver_Spawn_AddRacingClose ensures a task spawned by a task whose Add raced with Close still runs.
+func TestWeaver_Spawn_AddRacingClose(t *testing.T) {
+	for i := 0; i < 500; i++ {
+		w, err := NewWeaver(context.Background(), 1)
+		assert.NoError(t, err)
+
+		var spawned, ran atomic.Bool
+		added := make(chan error, 1)
+		go func() {
+			added <- w.Add(func(ctx context.Context) error {
+				if w.Spawn(func(ctx context.Context) error { ran.Store(true); return nil }) == nil {
+					spawned.Store(true)
+				}
+				return nil
+			})
+		}()
+
+		assert.NoError(t, w.Close())
+		if <-added == nil {
+			assert.Equal(t, spawned.Load(), ran.Load(), "An accepted spawned task must run before Close returns")
+		}
+	}
+}
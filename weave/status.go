@@ -0,0 +1,60 @@
+package weave
+
+import "errors"
+
+// Status describes how a Weaver's run ended, for metrics and logging.
+type Status int
+
+const (
+	// StatusRunning means Wait (or Close) has not returned yet.
+	StatusRunning Status = iota
+	// StatusCompleted means every task ran and succeeded.
+	StatusCompleted
+	// StatusFailed means a task returned an error or panicked.
+	StatusFailed
+	// StatusCanceled means the context passed to NewWeaver was canceled
+	// before all work was done, and no task failed for another reason.
+	StatusCanceled
+)
+
+// String returns the lower-case name of the status, e.g. "completed".
+func (s Status) String() string {
+	switch s {
+	case StatusRunning:
+		return "running"
+	case StatusCompleted:
+		return "completed"
+	case StatusFailed:
+		return "failed"
+	case StatusCanceled:
+		return "canceled"
+	}
+	return "unknown"
+}
+
+// Status reports how the Weaver finished, so callers need not infer it by
+// comparing the error from Wait against context.Canceled.
+//
+// It returns StatusRunning until the first Wait or Close call returns, and a
+// fixed final status afterwards. Tasks that fail by returning the error of
+// the canceled context count as canceled, not failed. It is safe to call
+// concurrently and repeatedly.
+func (w *Weaver) Status() Status {
+	return Status(w.status.Load())
+}
+
+// resolveStatus determines the final Status once all workers have stopped
+// and finalErr is set.
+func (w *Weaver) resolveStatus() Status {
+	parentErr := w.parent.Err()
+	if w.finalErr != nil && (parentErr == nil || !errors.Is(w.finalErr, parentErr)) {
+		return StatusFailed
+	}
+
+	// Outside cancellation only matters if it actually cut work short.
+	cutShort := w.finalErr != nil || w.interrupted.Load() || w.active.Load() > 0
+	if parentErr != nil && cutShort {
+		return StatusCanceled
+	}
+	return StatusCompleted
+}
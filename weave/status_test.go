@@ -0,0 +1,90 @@
+package weave
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestWeaver_Status_Completed verifies the status of a run in which every task succeeded.
+func TestWeaver_Status_Completed(t *testing.T) {
+	weaver, err := NewWeaver(context.Background(), 2)
+	assert.NoError(t, err)
+
+	assert.NoError(t, weaver.Add(func(ctx context.Context) error { return nil }))
+	assert.Equal(t, StatusRunning, weaver.Status(), "Status should be running before Wait returns")
+
+	assert.NoError(t, weaver.Wait())
+	assert.Equal(t, StatusCompleted, weaver.Status())
+	assert.Equal(t, StatusCompleted, weaver.Status(), "Status should be stable")
+}
+
+// TestWeaver_Status_Failed verifies that task errors and panics are reported as failures.
+func TestWeaver_Status_Failed(t *testing.T) {
+	tasks := map[string]Task{
+		"error": func(ctx context.Context) error { return errors.New("task failed") },
+		"panic": func(ctx context.Context) error { panic("boom") },
+	}
+
+	for name, task := range tasks {
+		t.Run(name, func(t *testing.T) {
+			weaver, err := NewWeaver(context.Background(), 1)
+			assert.NoError(t, err)
+
+			assert.NoError(t, weaver.Add(task))
+			assert.Error(t, weaver.Wait())
+			assert.Equal(t, StatusFailed, weaver.Status())
+		})
+	}
+}
+
+// TestWeaver_Status_Canceled verifies that outside cancellation is distinguished from task failure.
+func TestWeaver_Status_Canceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	weaver, err := NewWeaver(ctx, 1)
+	assert.NoError(t, err)
+
+	started := make(chan struct{})
+	assert.NoError(t, weaver.Add(func(ctx context.Context) error {
+		close(started)
+		<-ctx.Done()
+		return ctx.Err()
+	}))
+
+	<-started
+	cancel()
+
+	assert.ErrorIs(t, weaver.Wait(), context.Canceled)
+	assert.Equal(t, StatusCanceled, weaver.Status(), "Returning the context error should count as canceled")
+}
+
+// TestWeaver_Status_CanceledAfterCompletion ensures cancellation after all tasks finished still counts as completed.
+func TestWeaver_Status_CanceledAfterCompletion(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	weaver, err := NewWeaver(ctx, 1)
+	assert.NoError(t, err)
+
+	done := make(chan struct{})
+	assert.NoError(t, weaver.Add(func(ctx context.Context) error {
+		close(done)
+		return nil
+	}))
+	<-done
+	assert.Eventually(t, func() bool { return weaver.Stats().Pending == 0 }, time.Second, time.Millisecond)
+	cancel()
+
+	assert.NoError(t, weaver.Wait())
+	assert.Equal(t, StatusCompleted, weaver.Status())
+}
+
+// TestStatus_String verifies the names of the statuses.
+func TestStatus_String(t *testing.T) {
+	assert.Equal(t, "running", StatusRunning.String())
+	assert.Equal(t, "completed", StatusCompleted.String())
+	assert.Equal(t, "failed", StatusFailed.String())
+	assert.Equal(t, "canceled", StatusCanceled.String())
+	assert.Equal(t, "unknown", Status(42).String())
+}
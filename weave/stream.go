@@ -0,0 +1,98 @@
+package weave
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+)
+
+// Result is the outcome of a task submitted to a StreamWeaver.
+type Result[T any] struct {
+	Value T
+	Err   error
+}
+
+// StreamWeaver is a long-lived worker pool that delivers the result of each
+// task over a channel as soon as it completes, for consumers that process
+// outputs incrementally rather than collecting them at the end.
+//
+// Unlike Weaver, a failing task does not affect the others: every task
+// produces exactly one Result carrying its value or error, and panics are
+// reported as *PanicError. Results arrive in completion order.
+type StreamWeaver[T any] struct {
+	weaver  *Weaver
+	results chan Result[T]
+	closed  atomic.Bool
+	once    sync.Once
+}
+
+// NewStreamWeaver creates a StreamWeaver with a fixed concurrency limit and
+// starts its workers. Options are the same as for NewWeaver.
+//
+// If concurrency is less than or equal to zero, NewStreamWeaver returns an error.
+func NewStreamWeaver[T any](ctx context.Context, concurrency int, opts ...Option) (*StreamWeaver[T], error) {
+	w, err := NewWeaver(ctx, concurrency, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &StreamWeaver[T]{
+		weaver:  w,
+		results: make(chan Result[T], concurrency),
+	}, nil
+}
+
+// Submit enqueues a task, blocking while the queue is full. It returns an
+// error once Close has been called.
+//
+// Results must be consumed: when nobody reads from Results, workers block on
+// delivering their results and Submit eventually blocks as well.
+func (sw *StreamWeaver[T]) Submit(task ResultTask[T]) error {
+	if sw.closed.Load() {
+		return errors.New("weave: stream weaver is closed")
+	}
+
+	return sw.weaver.Add(func(ctx context.Context) error {
+		res := runResultTask(ctx, task)
+		select {
+		case sw.results <- res:
+		case <-ctx.Done():
+			// The Weaver's context is gone; nobody is expected to read anymore.
+		}
+		return nil
+	})
+}
+
+// runResultTask runs task, converting a panic into a *PanicError result.
+func runResultTask[T any](ctx context.Context, task ResultTask[T]) (res Result[T]) {
+	defer func() {
+		if r := recover(); r != nil {
+			res = Result[T]{Err: &PanicError{value: r}}
+		}
+	}()
+
+	res.Value, res.Err = task(ctx)
+	return res
+}
+
+// Results returns the channel on which task results are delivered. It is
+// closed once Close has been called and every submitted task has finished.
+func (sw *StreamWeaver[T]) Results() <-chan Result[T] {
+	return sw.results
+}
+
+// Close stops accepting new tasks. It does not block: tasks already
+// submitted keep running, and Results is closed after the last of them has
+// delivered its result. Calling Close more than once has no further effect.
+//
+// Tasks still queued when the parent context is canceled are skipped and
+// produce no result.
+func (sw *StreamWeaver[T]) Close() {
+	sw.once.Do(func() {
+		sw.closed.Store(true)
+		go func() {
+			sw.weaver.Wait()
+			close(sw.results)
+		}()
+	})
+}
@@ -0,0 +1,96 @@
+package weave
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestStreamWeaver verifies that every submitted task delivers exactly one result and the channel closes after Close.
+func TestStreamWeaver(t *testing.T) {
+	sw, err := NewStreamWeaver[int](context.Background(), 3)
+	assert.NoError(t, err)
+
+	failure := errors.New("odd job")
+	go func() {
+		for i := 0; i < 10; i++ {
+			i := i
+			assert.NoError(t, sw.Submit(func(ctx context.Context) (int, error) {
+				switch {
+				case i == 7:
+					panic("seven")
+				case i%2 == 1:
+					return 0, failure
+				}
+				return i * 10, nil
+			}))
+		}
+		sw.Close()
+	}()
+
+	var values []int
+	var failures, panics int
+	for res := range sw.Results() {
+		var panicErr *PanicError
+		switch {
+		case errors.As(res.Err, &panicErr):
+			panics++
+		case res.Err != nil:
+			assert.Equal(t, failure, res.Err)
+			failures++
+		default:
+			values = append(values, res.Value)
+		}
+	}
+
+	sort.Ints(values)
+	assert.Equal(t, []int{0, 20, 40, 60, 80}, values)
+	assert.Equal(t, 4, failures, "Failed tasks should not stop the others")
+	assert.Equal(t, 1, panics)
+}
+
+// TestStreamWeaver_IncrementalDelivery verifies that results arrive before the whole batch completes.
+func TestStreamWeaver_IncrementalDelivery(t *testing.T) {
+	sw, err := NewStreamWeaver[string](context.Background(), 2)
+	assert.NoError(t, err)
+	defer sw.Close()
+
+	release := make(chan struct{})
+	defer close(release)
+
+	assert.NoError(t, sw.Submit(func(ctx context.Context) (string, error) { return "fast", nil }))
+	assert.NoError(t, sw.Submit(func(ctx context.Context) (string, error) {
+		<-release
+		return "slow", nil
+	}))
+
+	select {
+	case res := <-sw.Results():
+		assert.Equal(t, "fast", res.Value)
+	case <-time.After(time.Second):
+		t.Fatal("The fast result should be delivered while the slow task is still running")
+	}
+}
+
+// TestStreamWeaver_SubmitAfterClose verifies that Close stops intake.
+func TestStreamWeaver_SubmitAfterClose(t *testing.T) {
+	sw, err := NewStreamWeaver[int](context.Background(), 1)
+	assert.NoError(t, err)
+
+	sw.Close()
+	sw.Close()
+
+	assert.Error(t, sw.Submit(func(ctx context.Context) (int, error) { return 1, nil }))
+	_, open := <-sw.Results()
+	assert.False(t, open, "Results should close once drained")
+}
+
+// TestNewStreamWeaver_InvalidConcurrency verifies that a non-positive concurrency is rejected.
+func TestNewStreamWeaver_InvalidConcurrency(t *testing.T) {
+	_, err := NewStreamWeaver[int](context.Background(), 0)
+	assert.Error(t, err)
+}
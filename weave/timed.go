@@ -0,0 +1,35 @@
+package weave
+
+import (
+	"context"
+	"time"
+)
+
+// Timed wraps task so that each run reports how long it took.
+//
+// record is called once per run, after the task returns, with its execution
+// time and the error it returned. If the task panics, record receives a
+// *PanicError and the panic is then re-raised, so Sail, Weaver, and the other
+// helpers recover and report it exactly as they would without the wrapper.
+//
+// Timed composes with any other func(Task) Task wrapper, and the result can
+// be passed anywhere a Task is accepted:
+//
+//	observe := func(d time.Duration, err error) {
+//		taskDuration.Observe(d.Seconds())
+//	}
+//	err := weave.Sail(ctx, weave.Timed(fetchUser, observe), weave.Timed(fetchOrders, observe))
+func Timed(task Task, record func(d time.Duration, err error)) Task {
+	return func(ctx context.Context) (err error) {
+		start := time.Now()
+		defer func() {
+			if r := recover(); r != nil {
+				record(time.Since(start), &PanicError{value: r})
+				panic(r)
+			}
+			record(time.Since(start), err)
+		}()
+
+		return task(ctx)
+	}
+}
@@ -0,0 +1,51 @@
+package weave
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestTimed_RecordsDurationAndError verifies that Timed reports the task's duration and result.
+func TestTimed_RecordsDurationAndError(t *testing.T) {
+	expectedErr := errors.New("task failed")
+
+	var gotD time.Duration
+	var gotErr error
+	task := Timed(func(ctx context.Context) error {
+		time.Sleep(20 * time.Millisecond)
+		return expectedErr
+	}, func(d time.Duration, err error) {
+		gotD, gotErr = d, err
+	})
+
+	assert.Equal(t, expectedErr, task(context.Background()))
+	assert.GreaterOrEqual(t, gotD, 20*time.Millisecond)
+	assert.Equal(t, expectedErr, gotErr)
+}
+
+// TestTimed_Panic verifies that panics are recorded as *PanicError and still reach Sail.
+func TestTimed_Panic(t *testing.T) {
+	var mu sync.Mutex
+	var recorded []error
+	record := func(d time.Duration, err error) {
+		mu.Lock()
+		defer mu.Unlock()
+		recorded = append(recorded, err)
+	}
+
+	err := Sail(context.Background(), Timed(func(ctx context.Context) error { panic("boom") }, record))
+
+	var panicErr *PanicError
+	assert.ErrorAs(t, err, &panicErr, "Sail should still recover the panic")
+
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Len(t, recorded, 1)
+	assert.ErrorAs(t, recorded[0], &panicErr)
+	assert.Equal(t, "boom", panicErr.Value())
+}
@@ -0,0 +1,86 @@
+package weave
+
+import (
+	"context"
+	"time"
+)
+
+// WeaverStats is a point-in-time snapshot of a Weaver's workload.
+type WeaverStats struct {
+	// Pending is the number of accepted tasks that are queued or running.
+	Pending int64
+	// Orphaned is the number of goroutines abandoned by AddWithHardTimeout
+	// that are still running in the background.
+	Orphaned int64
+}
+
+// Stats returns a snapshot of the Weaver's workload. It is safe to call
+// concurrently, e.g. from a metrics collector.
+func (w *Weaver) Stats() WeaverStats {
+	return WeaverStats{
+		Pending:  w.active.Load(),
+		Orphaned: w.orphaned.Load(),
+	}
+}
+
+// AddWithHardTimeout submits a task like Add, but gives it at most d to run,
+// even if it ignores its context.
+//
+// The task runs on its own goroutine with a context that expires after d.
+// If it has not returned by then, the worker records context.DeadlineExceeded
+// as the task's error and moves on to the next task, abandoning the task
+// goroutine; the same happens with context.Canceled if the Weaver's context is
+// canceled first.
+//
+// Abandoned goroutines are not stopped: they keep running, and holding
+// whatever they hold, until the task returns on its own. A task that never
+// returns leaks its goroutine for the lifetime of the process, and Wait does
+// not wait for it. Stats reports how many are still running. Their results,
+// including panics, are discarded. Prefer cooperative cancellation, and use
+// this only as a safety net for calls that cannot be interrupted.
+func (w *Weaver) AddWithHardTimeout(d time.Duration, task Task) error {
+	return w.Add(w.hardTimeout(d, task))
+}
+
+// taskOutcome is what a task goroutine reports back to its worker.
+type taskOutcome struct {
+	err      error
+	panicVal any
+	panicked bool
+}
+
+// hardTimeout wraps task so that the worker running it stops waiting after d.
+func (w *Weaver) hardTimeout(d time.Duration, task Task) Task {
+	return func(ctx context.Context) error {
+		ctx, cancel := context.WithTimeout(ctx, d)
+
+		done := make(chan taskOutcome, 1)
+		go func() {
+			defer func() {
+				if r := recover(); r != nil {
+					done <- taskOutcome{panicVal: r, panicked: true}
+				}
+			}()
+			done <- taskOutcome{err: task(ctx)}
+		}()
+
+		select {
+		case out := <-done:
+			cancel()
+			if out.panicked {
+				// Re-raise on the worker so it is handled like any other panic.
+				panic(out.panicVal)
+			}
+			return out.err
+		case <-ctx.Done():
+			err := ctx.Err()
+			w.orphaned.Add(1)
+			go func() {
+				<-done
+				cancel()
+				w.orphaned.Add(-1)
+			}()
+			return err
+		}
+	}
+}
@@ -0,0 +1,72 @@
+package weave
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestWeaver_AddWithHardTimeout_Abandons verifies that a worker moves on from a task that ignores its context.
+func TestWeaver_AddWithHardTimeout_Abandons(t *testing.T) {
+	weaver, err := NewWeaver(context.Background(), 1, WithContinueOnError())
+	assert.NoError(t, err)
+
+	release := make(chan struct{})
+	assert.NoError(t, weaver.AddWithHardTimeout(20*time.Millisecond, func(ctx context.Context) error {
+		<-release // Ignores ctx entirely.
+		return nil
+	}))
+
+	ran := make(chan struct{})
+	assert.NoError(t, weaver.Add(func(ctx context.Context) error {
+		close(ran)
+		return nil
+	}))
+
+	select {
+	case <-ran:
+	case <-time.After(time.Second):
+		t.Fatal("the worker did not move on from the timed-out task")
+	}
+
+	assert.ErrorIs(t, weaver.Wait(), context.DeadlineExceeded)
+	assert.Equal(t, int64(1), weaver.Stats().Orphaned, "The abandoned goroutine should be counted")
+
+	close(release)
+	assert.Eventually(t, func() bool { return weaver.Stats().Orphaned == 0 }, time.Second, time.Millisecond,
+		"The orphan count should drop once the task returns")
+}
+
+// TestWeaver_AddWithHardTimeout_InTime ensures tasks finishing within the limit behave like Add.
+func TestWeaver_AddWithHardTimeout_InTime(t *testing.T) {
+	weaver, err := NewWeaver(context.Background(), 1)
+	assert.NoError(t, err)
+
+	expectedErr := errors.New("task failed")
+	assert.NoError(t, weaver.AddWithHardTimeout(time.Second, func(ctx context.Context) error {
+		_, hasDeadline := ctx.Deadline()
+		assert.True(t, hasDeadline, "The task context should carry the deadline")
+		return expectedErr
+	}))
+
+	assert.Equal(t, expectedErr, weaver.Wait())
+	assert.Equal(t, WeaverStats{}, weaver.Stats())
+}
+
+// TestWeaver_AddWithHardTimeout_Panic ensures panics inside the task goroutine are recovered by the worker.
+func TestWeaver_AddWithHardTimeout_Panic(t *testing.T) {
+	weaver, err := NewWeaver(context.Background(), 1)
+	assert.NoError(t, err)
+
+	assert.NoError(t, weaver.AddWithHardTimeout(time.Second, func(ctx context.Context) error {
+		panic("boom")
+	}))
+
+	err = weaver.Wait()
+	var panicErr *PanicError
+	assert.ErrorAs(t, err, &panicErr)
+	assert.ErrorContains(t, err, "worker 0 panic recovered: boom")
+}
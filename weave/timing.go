@@ -0,0 +1,93 @@
+package weave
+
+import (
+	"sync"
+	"time"
+)
+
+// Debouncer delays calls to a function until a quiet period has elapsed.
+// It is safe for concurrent use by multiple goroutines.
+type Debouncer struct {
+	mu      sync.Mutex
+	d       time.Duration
+	fn      func()
+	timer   *time.Timer
+	stopped bool
+}
+
+// Debounce returns a Debouncer whose Call method runs fn only after d has
+// passed without another call. Each call restarts the quiet period.
+//
+// fn runs on its own goroutine. Call Stop to cancel a pending invocation and
+// release the timer.
+func Debounce(d time.Duration, fn func()) *Debouncer {
+	return &Debouncer{d: d, fn: fn}
+}
+
+// Call schedules fn to run once d has elapsed without further calls.
+// Calls made after Stop are ignored.
+func (db *Debouncer) Call() {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	if db.stopped {
+		return
+	}
+	if db.timer != nil {
+		db.timer.Stop()
+	}
+	db.timer = time.AfterFunc(db.d, db.fn)
+}
+
+// Stop cancels any pending invocation and disables further calls.
+func (db *Debouncer) Stop() {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	db.stopped = true
+	if db.timer != nil {
+		db.timer.Stop()
+		db.timer = nil
+	}
+}
+
+// Throttler limits calls to a function to at most once per interval.
+// It is safe for concurrent use by multiple goroutines.
+type Throttler struct {
+	mu      sync.Mutex
+	d       time.Duration
+	fn      func()
+	last    time.Time
+	stopped bool
+}
+
+// Throttle returns a Throttler whose Call method runs fn at most once per d.
+//
+// The first call runs fn immediately on the caller's goroutine; calls made
+// within d of the last run are dropped. Call Stop to disable it.
+func Throttle(d time.Duration, fn func()) *Throttler {
+	return &Throttler{d: d, fn: fn}
+}
+
+// Call runs fn if at least d has passed since it last ran, and reports
+// whether it did.
+func (th *Throttler) Call() bool {
+	th.mu.Lock()
+	now := time.Now()
+	if th.stopped || (!th.last.IsZero() && now.Sub(th.last) < th.d) {
+		th.mu.Unlock()
+		return false
+	}
+	th.last = now
+	th.mu.Unlock()
+
+	th.fn()
+	return true
+}
+
+// Stop disables further calls.
+func (th *Throttler) Stop() {
+	th.mu.Lock()
+	defer th.mu.Unlock()
+	th.stopped = true
+}
@@ -0,0 +1,61 @@
+package weave
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestDebounce verifies that a burst of calls results in a single invocation after the quiet period.
+func TestDebounce(t *testing.T) {
+	var calls int32
+	db := Debounce(20*time.Millisecond, func() { atomic.AddInt32(&calls, 1) })
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			db.Call()
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, int32(0), atomic.LoadInt32(&calls), "fn should not run before the quiet period")
+	time.Sleep(60 * time.Millisecond)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls), "A burst should collapse into one call")
+}
+
+// TestDebounce_Stop ensures Stop cancels a pending invocation.
+func TestDebounce_Stop(t *testing.T) {
+	var calls int32
+	db := Debounce(20*time.Millisecond, func() { atomic.AddInt32(&calls, 1) })
+
+	db.Call()
+	db.Stop()
+	db.Call()
+	time.Sleep(50 * time.Millisecond)
+
+	assert.Equal(t, int32(0), atomic.LoadInt32(&calls), "Stopped debouncers should never fire")
+}
+
+// TestThrottle verifies that fn runs at most once per interval.
+func TestThrottle(t *testing.T) {
+	var calls int32
+	th := Throttle(30*time.Millisecond, func() { atomic.AddInt32(&calls, 1) })
+
+	assert.True(t, th.Call(), "The first call should run immediately")
+	assert.False(t, th.Call(), "Calls within the interval should be dropped")
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+
+	time.Sleep(40 * time.Millisecond)
+	assert.True(t, th.Call(), "Calls after the interval should run")
+	assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+
+	th.Stop()
+	time.Sleep(40 * time.Millisecond)
+	assert.False(t, th.Call(), "Stopped throttlers should not run")
+}
@@ -3,6 +3,8 @@ package weave
 import (
 	"context"
 	"errors"
+	"fmt"
+	"sync"
 	"sync/atomic"
 	"testing"
 	"time"
@@ -53,6 +55,41 @@ func TestSail_Panic(t *testing.T) {
 	assert.Contains(t, err.Error(), "panic recovered: something went wrong")
 }
 
+// TestSail_ErrorCancelsPeers verifies that in-flight tasks observe cancellation once a sibling fails or panics.
+func TestSail_ErrorCancelsPeers(t *testing.T) {
+	failures := map[string]Task{
+		"error": func(ctx context.Context) error { return errors.New("task failed") },
+		"panic": func(ctx context.Context) error { panic("boom") },
+	}
+
+	for name, fail := range failures {
+		t.Run(name, func(t *testing.T) {
+			started := make(chan struct{})
+			observed := make(chan error, 1)
+			peer := func(ctx context.Context) error {
+				close(started)
+				<-ctx.Done()
+				observed <- ctx.Err()
+				return nil
+			}
+			failAfterStart := func(ctx context.Context) error {
+				<-started
+				return fail(ctx)
+			}
+
+			err := Sail(context.Background(), peer, failAfterStart)
+			assert.Error(t, err)
+
+			select {
+			case err := <-observed:
+				assert.ErrorIs(t, err, context.Canceled)
+			case <-time.After(time.Second):
+				t.Fatal("peer task was not canceled after its sibling failed")
+			}
+		})
+	}
+}
+
 // TestSail_ContextCancel ensures Sail respects external context cancellation.
 func TestSail_ContextCancel(t *testing.T) {
 	ctx, cancel := context.WithCancel(context.Background())
@@ -67,6 +104,109 @@ func TestSail_ContextCancel(t *testing.T) {
 	assert.Equal(t, context.Canceled, err)
 }
 
+// TestSail_AlreadyCanceled ensures Sail returns immediately without running tasks on a canceled context.
+func TestSail_AlreadyCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	var started int32
+	task := func(ctx context.Context) error {
+		atomic.AddInt32(&started, 1)
+		return nil
+	}
+
+	err := Sail(ctx, task, task, task)
+	assert.Equal(t, context.Canceled, err)
+	assert.Equal(t, int32(0), atomic.LoadInt32(&started), "No task should run on an already-canceled context")
+}
+
+// TestSailIndexed verifies that every task runs and errors are reported by position.
+func TestSailIndexed(t *testing.T) {
+	expectedErr := errors.New("task 1 failed")
+	var completed int32
+
+	errs := SailIndexed(context.Background(),
+		func(ctx context.Context) error {
+			atomic.AddInt32(&completed, 1)
+			return nil
+		},
+		func(ctx context.Context) error { return expectedErr },
+		func(ctx context.Context) error { panic("task 2 exploded") },
+		func(ctx context.Context) error {
+			time.Sleep(20 * time.Millisecond)
+			atomic.AddInt32(&completed, 1)
+			return nil
+		},
+	)
+
+	assert.Len(t, errs, 4)
+	assert.NoError(t, errs[0])
+	assert.Equal(t, expectedErr, errs[1])
+	var panicErr *PanicError
+	assert.ErrorAs(t, errs[2], &panicErr, "Panics should be reported as *PanicError")
+	assert.ErrorContains(t, errs[2], "task 2 exploded")
+	assert.NoError(t, errs[3])
+	assert.Equal(t, int32(2), atomic.LoadInt32(&completed), "Failures should not stop other tasks")
+}
+
+// TestSailBest_CollectsWithinDeadline verifies that only timely successes are returned and laggards are canceled.
+func TestSailBest_CollectsWithinDeadline(t *testing.T) {
+	laggardCanceled := make(chan struct{})
+
+	start := time.Now()
+	results := SailBest(context.Background(), 50*time.Millisecond,
+		func(ctx context.Context) (string, error) { return "fast", nil },
+		func(ctx context.Context) (string, error) { return "", errors.New("source down") },
+		func(ctx context.Context) (string, error) { panic("broken source") },
+		func(ctx context.Context) (string, error) {
+			<-ctx.Done()
+			close(laggardCanceled)
+			return "slow", nil
+		},
+	)
+
+	assert.Equal(t, []string{"fast"}, results, "Errors, panics, and late results should be dropped")
+	assert.Less(t, time.Since(start), time.Second, "SailBest should return at the deadline")
+
+	select {
+	case <-laggardCanceled:
+	case <-time.After(time.Second):
+		t.Fatal("the laggard task was not canceled")
+	}
+}
+
+// TestSailBest_AllFinishEarly ensures SailBest returns without waiting for the deadline once every task is done.
+func TestSailBest_AllFinishEarly(t *testing.T) {
+	task := func(n int) ResultTask[int] {
+		return func(ctx context.Context) (int, error) { return n, nil }
+	}
+
+	start := time.Now()
+	results := SailBest(context.Background(), 10*time.Second, task(1), task(2), task(3))
+
+	assert.ElementsMatch(t, []int{1, 2, 3}, results)
+	assert.Less(t, time.Since(start), time.Second)
+
+	assert.Empty(t, SailBest[int](context.Background(), time.Second), "No tasks should yield an empty result")
+}
+
+// TestSailIndexed_AlreadyCanceled verifies that tasks are not started on a canceled context.
+func TestSailIndexed_AlreadyCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	var ran int32
+	task := func(ctx context.Context) error {
+		atomic.AddInt32(&ran, 1)
+		return nil
+	}
+
+	errs := SailIndexed(ctx, task, task)
+
+	assert.Equal(t, []error{context.Canceled, context.Canceled}, errs)
+	assert.Equal(t, int32(0), atomic.LoadInt32(&ran))
+}
+
 //
 // ────────────────────────────────────────────────
 //   TESTS FOR WEAVER
@@ -224,3 +364,360 @@ func TestWeaver_Wait_Idempotent(t *testing.T) {
 	assert.Error(t, err2)
 	assert.Equal(t, expectedErr, err2)
 }
+
+// TestWeaver_Close_DrainsQueue verifies that Close stops intake but finishes queued tasks without canceling the context.
+func TestWeaver_Close_DrainsQueue(t *testing.T) {
+	weaver, err := NewWeaver(context.Background(), 2)
+	assert.NoError(t, err)
+
+	var completed int32
+	var lastCtx atomic.Value
+	for i := 0; i < 20; i++ {
+		assert.NoError(t, weaver.Add(func(ctx context.Context) error {
+			time.Sleep(time.Millisecond)
+			atomic.AddInt32(&completed, 1)
+			lastCtx.Store(ctx)
+			return nil
+		}))
+	}
+
+	err = weaver.Close()
+	assert.NoError(t, err)
+	assert.Equal(t, int32(20), atomic.LoadInt32(&completed), "Close should drain every queued task")
+	assert.NoError(t, lastCtx.Load().(context.Context).Err(), "Close should not cancel the task context")
+
+	err = weaver.Add(func(ctx context.Context) error { return nil })
+	assert.Error(t, err, "Add should fail once Close has been called")
+
+	assert.NoError(t, weaver.Wait(), "Wait after Close should return the same result")
+	assert.Error(t, lastCtx.Load().(context.Context).Err(), "Wait should release the context")
+}
+
+// TestWeaver_WaitContext verifies that WaitContext stops waiting on its own context without canceling the tasks.
+func TestWeaver_WaitContext(t *testing.T) {
+	weaver, err := NewWeaver(context.Background(), 1)
+	assert.NoError(t, err)
+
+	release := make(chan struct{})
+	expectedErr := errors.New("late failure")
+	var taskCtx atomic.Value
+	assert.NoError(t, weaver.Add(func(ctx context.Context) error {
+		taskCtx.Store(ctx)
+		<-release
+		return expectedErr
+	}))
+
+	waitCtx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	err = weaver.WaitContext(waitCtx)
+	assert.ErrorIs(t, err, context.DeadlineExceeded, "WaitContext should give up when its context expires")
+	assert.NoError(t, taskCtx.Load().(context.Context).Err(), "Tasks should keep running on their own context")
+	assert.Error(t, weaver.Add(func(ctx context.Context) error { return nil }), "The Weaver should be closed to new tasks")
+
+	close(release)
+	assert.Equal(t, expectedErr, weaver.Wait(), "A later Wait should still collect the result")
+	assert.Equal(t, expectedErr, weaver.WaitContext(context.Background()))
+}
+
+// TestWeaver_Close_ReturnsFirstError ensures Close reports the first task error like Wait.
+func TestWeaver_Close_ReturnsFirstError(t *testing.T) {
+	weaver, err := NewWeaver(context.Background(), 1)
+	assert.NoError(t, err)
+
+	expectedErr := errors.New("task failed")
+	assert.NoError(t, weaver.Add(func(ctx context.Context) error { return expectedErr }))
+
+	assert.Equal(t, expectedErr, weaver.Close())
+	assert.Equal(t, expectedErr, weaver.Wait())
+}
+
+// TestWeaver_WithRepanic verifies that the first task panic is re-raised from Wait.
+func TestWeaver_WithRepanic(t *testing.T) {
+	weaver, err := NewWeaver(context.Background(), 2, WithRepanic())
+	assert.NoError(t, err)
+
+	var completed int32
+	finished := make(chan struct{})
+	assert.NoError(t, weaver.Add(func(ctx context.Context) error {
+		<-finished
+		panic("fatal task")
+	}))
+	assert.NoError(t, weaver.Add(func(ctx context.Context) error {
+		atomic.AddInt32(&completed, 1)
+		close(finished)
+		return nil
+	}))
+
+	assert.PanicsWithValue(t, "fatal task", func() { weaver.Wait() }, "Wait should re-raise the task panic")
+	assert.Equal(t, int32(1), atomic.LoadInt32(&completed), "Other tasks should finish before the panic is re-raised")
+
+	err = weaver.Wait()
+	assert.ErrorContains(t, err, "fatal task", "Subsequent Wait calls should report the panic as an error")
+}
+
+// TestWeaver_WaitPanicked_ConsistentForAllCallers verifies that every caller observes ErrWaitPanicked after the closer panics.
+func TestWeaver_WaitPanicked_ConsistentForAllCallers(t *testing.T) {
+	weaver, err := NewWeaver(context.Background(), 1, WithRepanic())
+	assert.NoError(t, err)
+
+	release := make(chan struct{})
+	assert.NoError(t, weaver.Add(func(ctx context.Context) error {
+		<-release
+		panic("closer path")
+	}))
+
+	const callers = 4
+	results := make(chan any, callers)
+	for i := 0; i < callers; i++ {
+		go func() {
+			defer func() {
+				if r := recover(); r != nil {
+					results <- r
+				}
+			}()
+			results <- weaver.Wait()
+		}()
+	}
+
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+
+	var panics, errs int
+	for i := 0; i < callers; i++ {
+		switch r := (<-results).(type) {
+		case error:
+			errs++
+			assert.ErrorIs(t, r, ErrWaitPanicked, "Concurrent callers should observe the sentinel, not a zero value")
+			var panicErr *PanicError
+			if assert.ErrorAs(t, r, &panicErr) {
+				assert.Equal(t, "closer path", panicErr.Value())
+			}
+		default:
+			panics++
+			assert.Equal(t, "closer path", r)
+		}
+	}
+	assert.Equal(t, 1, panics, "Only the closer should re-raise the panic")
+	assert.Equal(t, callers-1, errs)
+
+	assert.ErrorIs(t, weaver.Wait(), ErrWaitPanicked, "Later callers should observe the sentinel too")
+	assert.ErrorIs(t, weaver.Close(), ErrWaitPanicked)
+}
+
+// TestWeaver_Err verifies that the first error can be observed while the Weaver is still running.
+func TestWeaver_Err(t *testing.T) {
+	weaver, err := NewWeaver(context.Background(), 2)
+	assert.NoError(t, err)
+	assert.NoError(t, weaver.Err(), "Err should be nil before any task fails")
+
+	expectedErr := errors.New("task failed")
+	block := make(chan struct{})
+	assert.NoError(t, weaver.Add(func(ctx context.Context) error { return expectedErr }))
+	assert.NoError(t, weaver.Add(func(ctx context.Context) error {
+		<-block
+		return nil
+	}))
+
+	assert.Eventually(t, func() bool { return weaver.Err() != nil }, time.Second, time.Millisecond)
+	assert.Equal(t, expectedErr, weaver.Err(), "Err should expose the first error while tasks are running")
+
+	close(block)
+	assert.Equal(t, expectedErr, weaver.Wait(), "Err should not consume the error returned by Wait")
+}
+
+// TestWeaver_WithValue verifies that values attached after construction reach subsequently executed tasks.
+func TestWeaver_WithValue(t *testing.T) {
+	type ctxKey string
+
+	weaver, err := NewWeaver(context.Background(), 1)
+	assert.NoError(t, err)
+
+	weaver.WithValue(ctxKey("trace"), "abc123")
+	weaver.WithValue(ctxKey("user"), "cassie")
+
+	var trace, user atomic.Value
+	assert.NoError(t, weaver.Add(func(ctx context.Context) error {
+		trace.Store(ctx.Value(ctxKey("trace")))
+		user.Store(ctx.Value(ctxKey("user")))
+		return nil
+	}))
+
+	assert.NoError(t, weaver.Wait())
+	assert.Equal(t, "abc123", trace.Load())
+	assert.Equal(t, "cassie", user.Load())
+}
+
+// TestWeaver_WithValue_PreservesCancellation ensures task contexts still observe Weaver cancellation.
+func TestWeaver_WithValue_PreservesCancellation(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	weaver, err := NewWeaver(ctx, 1)
+	assert.NoError(t, err)
+	weaver.WithValue("key", "value")
+
+	started := make(chan struct{})
+	done := make(chan error, 1)
+	assert.NoError(t, weaver.Add(func(ctx context.Context) error {
+		close(started)
+		<-ctx.Done()
+		done <- ctx.Err()
+		return nil
+	}))
+
+	<-started
+	cancel()
+	assert.Equal(t, context.Canceled, <-done)
+	weaver.Wait()
+}
+
+// TestWeaver_AddCtx_Timeout verifies that AddCtx gives up when the queue stays full.
+func TestWeaver_AddCtx_Timeout(t *testing.T) {
+	weaver, err := NewWeaver(context.Background(), 1)
+	assert.NoError(t, err)
+
+	block := make(chan struct{})
+	blocking := func(ctx context.Context) error {
+		<-block
+		return nil
+	}
+
+	// One task occupies the worker, one fills the queue buffer.
+	assert.NoError(t, weaver.AddCtx(context.Background(), blocking))
+	assert.NoError(t, weaver.AddCtx(context.Background(), blocking))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	err = weaver.AddCtx(ctx, blocking)
+	assert.ErrorIs(t, err, context.DeadlineExceeded, "AddCtx should return when its context expires")
+
+	close(block)
+	assert.NoError(t, weaver.Wait())
+}
+
+// TestWeaver_AddCtx_Stopped ensures AddCtx does not hang once the Weaver's context is canceled.
+func TestWeaver_AddCtx_Stopped(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	weaver, err := NewWeaver(ctx, 1)
+	assert.NoError(t, err)
+	cancel()
+
+	err = weaver.AddCtx(context.Background(), func(ctx context.Context) error { return nil })
+	assert.ErrorContains(t, err, "weaver is stopped")
+	weaver.Wait()
+}
+
+// TestWeaver_AddCtx_Closed ensures AddCtx rejects tasks after Wait.
+func TestWeaver_AddCtx_Closed(t *testing.T) {
+	weaver, err := NewWeaver(context.Background(), 1)
+	assert.NoError(t, err)
+	assert.NoError(t, weaver.Wait())
+
+	err = weaver.AddCtx(context.Background(), func(ctx context.Context) error { return nil })
+	assert.ErrorContains(t, err, "weaver is closed")
+}
+
+// TestWeaver_WorkerLabels verifies that panic errors name the worker and OnError sees every failure.
+func TestWeaver_WorkerLabels(t *testing.T) {
+	var failures int32
+	var panicMsg, wantPanicMsg atomic.Value
+	weaver, err := NewWeaver(context.Background(), 2, WithOnError(func(workerID int, err error) {
+		atomic.AddInt32(&failures, 1)
+		if _, ok := err.(*PanicError); ok {
+			panicMsg.Store(err.Error())
+			wantPanicMsg.Store(fmt.Sprintf("worker %d panic recovered: boom", workerID))
+		}
+	}))
+	assert.NoError(t, err)
+
+	// Both tasks must be running before either fails, or the first failure
+	// would cancel the other before it starts.
+	var started sync.WaitGroup
+	started.Add(2)
+	assert.NoError(t, weaver.Add(func(ctx context.Context) error {
+		started.Done()
+		started.Wait()
+		panic("boom")
+	}))
+	assert.NoError(t, weaver.Add(func(ctx context.Context) error {
+		started.Done()
+		started.Wait()
+		return errors.New("second")
+	}))
+
+	weaver.Wait()
+	assert.Equal(t, int32(2), atomic.LoadInt32(&failures), "OnError should be called for every failure")
+	assert.Equal(t, wantPanicMsg.Load(), panicMsg.Load(), "Panic errors should name the worker")
+}
+
+// TestWeaver_ErrorCancelsPeers verifies that a long-running task exits promptly once a sibling fails.
+func TestWeaver_ErrorCancelsPeers(t *testing.T) {
+	weaver, err := NewWeaver(context.Background(), 2)
+	assert.NoError(t, err)
+
+	started := make(chan struct{})
+	observed := make(chan error, 1)
+	assert.NoError(t, weaver.Add(func(ctx context.Context) error {
+		close(started)
+		select {
+		case <-ctx.Done():
+			observed <- ctx.Err()
+		case <-time.After(5 * time.Second):
+			observed <- nil
+		}
+		return nil
+	}))
+
+	expectedErr := errors.New("sibling failed")
+	assert.NoError(t, weaver.Add(func(ctx context.Context) error {
+		<-started
+		return expectedErr
+	}))
+
+	start := time.Now()
+	assert.Equal(t, expectedErr, weaver.Wait())
+	assert.ErrorIs(t, <-observed, context.Canceled, "The long-running task should observe cancellation")
+	assert.Less(t, time.Since(start), time.Second, "Wait should not wait out the long-running task")
+}
+
+// TestWeaver_WithContinueOnError verifies that every task runs and all errors are joined.
+func TestWeaver_WithContinueOnError(t *testing.T) {
+	weaver, err := NewWeaver(context.Background(), 1, WithContinueOnError())
+	assert.NoError(t, err)
+
+	errA := errors.New("task a failed")
+	errB := errors.New("task b failed")
+
+	var completed int32
+	ok := func(ctx context.Context) error {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		atomic.AddInt32(&completed, 1)
+		return nil
+	}
+
+	assert.NoError(t, weaver.Add(func(ctx context.Context) error { return errA }))
+	assert.NoError(t, weaver.Add(ok))
+	assert.NoError(t, weaver.Add(func(ctx context.Context) error { panic("task c exploded") }))
+	assert.NoError(t, weaver.Add(ok))
+	assert.NoError(t, weaver.Add(func(ctx context.Context) error { return errB }))
+
+	err = weaver.Wait()
+	assert.ErrorIs(t, err, errA)
+	assert.ErrorIs(t, err, errB)
+	var panicErr *PanicError
+	assert.ErrorAs(t, err, &panicErr, "Panics should be collected too")
+	assert.Equal(t, int32(2), atomic.LoadInt32(&completed), "Errors should not cancel the other tasks")
+	assert.Equal(t, errA, weaver.Err(), "Err should report the first error")
+
+	assert.Equal(t, err, weaver.Wait(), "Later Wait calls should return the same joined error")
+}
+
+// TestWeaver_WithContinueOnError_NoErrors ensures Wait returns nil when every task succeeds.
+func TestWeaver_WithContinueOnError_NoErrors(t *testing.T) {
+	weaver, err := NewWeaver(context.Background(), 2, WithContinueOnError())
+	assert.NoError(t, err)
+
+	assert.NoError(t, weaver.Add(func(ctx context.Context) error { return nil }))
+	assert.NoError(t, weaver.Wait())
+}
@@ -8,8 +8,15 @@ import (
 	"fmt"
 	"sync"
 	"sync/atomic"
+	"time"
 )
 
+// ErrWaitPanicked is reported by Wait and Close after the goroutine that
+// closed the Weaver panicked, e.g. while re-raising a task panic with
+// WithRepanic. The returned error wraps it together with a *PanicError
+// carrying the panic value.
+var ErrWaitPanicked = errors.New("weave: wait panic")
+
 // Weaver manages a pool of worker goroutines that execute tasks with
 // bounded concurrency. It guarantees safe task submission, panic
 // recovery, and deterministic shutdown.
@@ -18,22 +25,55 @@ import (
 // with cancellation and consistent error propagation — similar in
 // spirit to errgroup, but with explicit concurrency limits and lifecycle
 // control.
+//
+// Like errgroup.WithContext, the first task error or panic cancels the context
+// passed to tasks: in-flight tasks observe ctx.Done() and can stop early, and
+// tasks still in the queue are skipped. Pass WithContinueOnError to run every
+// task and collect all errors instead.
 type Weaver struct {
 	wg        sync.WaitGroup
 	errOnce   sync.Once
 	errChan   chan error
+	firstErr  atomic.Pointer[error] // Readable view of the first error, see Err.
 	taskQueue chan Task
 	cancel    func()
+	parent    context.Context                 // Context passed to NewWeaver, to tell outside cancellation apart.
+	taskCtx   atomic.Pointer[context.Context] // Context handed to tasks; extended by WithValue.
 	isClosed  atomic.Bool
+	drained   chan struct{} // Closed once all workers have exited and finalErr is set.
 	finalErr  error
+	opts      options
+
+	errMu sync.Mutex
+	errs  []error // Every task error, recorded only with WithContinueOnError.
+
+	// Outstanding work, so Close can wait for tasks that may still Spawn more.
+	active       atomic.Int64  // Tasks accepted by Add or Spawn but not yet finished.
+	intakeMu     sync.RWMutex  // Orders Add's admission against Close setting closing, see admit.
+	closing      atomic.Bool   // Set by Close before it waits for active to reach zero.
+	quiesced     chan struct{} // Closed once closing is set and active is zero.
+	quiesceOnce  sync.Once
+	spawnMu      sync.Mutex
+	spawnBacklog []Task // Spawned tasks waiting to be fed into taskQueue.
+	feeding      bool   // Whether the feeder goroutine is running.
+
+	orphaned atomic.Int64 // Abandoned AddWithHardTimeout tasks still running.
+
+	interrupted atomic.Bool  // A queued task was skipped because the context was done.
+	status      atomic.Int32 // Status, settled when Close resolves.
+
+	panicOnce  sync.Once
+	panicValue any // First recovered task panic, re-raised by Wait when repanic is set.
 }
 
 // NewWeaver creates a new Weaver with a fixed concurrency limit.
 // It launches 'concurrency' worker goroutines immediately and
 // returns an initialized Weaver instance.
 //
+// Optional behavior can be enabled by passing Option values.
+//
 // If concurrency is less than or equal to zero, NewWeaver returns an error.
-func NewWeaver(ctx context.Context, concurrency int) (*Weaver, error) {
+func NewWeaver(ctx context.Context, concurrency int, opts ...Option) (*Weaver, error) {
 	if concurrency <= 0 {
 		return nil, errors.New("weave: concurrency must be greater than 0")
 	}
@@ -44,11 +84,18 @@ func NewWeaver(ctx context.Context, concurrency int) (*Weaver, error) {
 		taskQueue: make(chan Task, concurrency),
 		errChan:   make(chan error, 1),
 		cancel:    cancel,
+		parent:    ctx,
+		drained:   make(chan struct{}),
+		quiesced:  make(chan struct{}),
+	}
+	for _, opt := range opts {
+		opt(&w.opts)
 	}
+	w.taskCtx.Store(&workerCtx)
 
 	w.wg.Add(concurrency)
 	for i := 0; i < concurrency; i++ {
-		go w.worker(workerCtx)
+		go w.worker(workerCtx, i)
 	}
 
 	return w, nil
@@ -56,8 +103,8 @@ func NewWeaver(ctx context.Context, concurrency int) (*Weaver, error) {
 
 // worker continuously pulls tasks from the queue and executes them.
 // It terminates when the queue is closed or when the parent context
-// is canceled.
-func (w *Weaver) worker(ctx context.Context) {
+// is canceled. id identifies the worker in panic messages and callbacks.
+func (w *Weaver) worker(ctx context.Context, id int) {
 	defer w.wg.Done()
 	for {
 		select {
@@ -65,7 +112,8 @@ func (w *Weaver) worker(ctx context.Context) {
 			if !ok {
 				return
 			}
-			w.execute(ctx, task)
+			w.execute(*w.taskCtx.Load(), id, task)
+			w.taskDone()
 		case <-ctx.Done():
 			return
 		}
@@ -74,80 +122,301 @@ func (w *Weaver) worker(ctx context.Context) {
 
 // execute runs a single task with panic protection and cooperative
 // context cancellation. If a task returns an error or panics, the first
-// such error is recorded for retrieval by Wait.
-func (w *Weaver) execute(ctx context.Context, task Task) {
+// such error is recorded for retrieval by Wait and cancels the remaining
+// work; every failure is reported to the OnError callback, if any.
+func (w *Weaver) execute(ctx context.Context, id int, task Task) {
+	if ctx.Err() != nil {
+		w.interrupted.Store(true)
+		return
+	}
+
+	var start time.Time
+	if w.opts.logger != nil {
+		start = time.Now()
+		w.logTaskStart(id)
+	}
+
 	defer func() {
 		if r := recover(); r != nil {
-			w.sendErr(fmt.Errorf("panic recovered: %v", r))
+			if w.opts.repanic {
+				w.panicOnce.Do(func() { w.panicValue = r })
+			}
+			err := &PanicError{value: r, prefix: fmt.Sprintf("worker %d ", id)}
+			w.logTaskDone(id, start, err)
+			w.fail(id, err)
 		}
 	}()
-	if ctx.Err() != nil {
-		return
+
+	err := task(ctx)
+	if w.opts.logger != nil {
+		w.logTaskDone(id, start, err)
+	}
+	if err != nil {
+		w.fail(id, err)
+	}
+}
+
+// admit counts a task submitted by Add or AddCtx as outstanding, unless Close
+// has begun. The check and the increment happen under intakeMu, so Close
+// cannot find no outstanding work, and close the queue, between the two:
+// the admitted task must still be enqueued, and may Spawn more.
+func (w *Weaver) admit() bool {
+	w.intakeMu.RLock()
+	defer w.intakeMu.RUnlock()
+
+	if w.isClosed.Load() {
+		return false
+	}
+	w.active.Add(1)
+	return true
+}
+
+// taskDone records that an accepted task has finished (or was dropped),
+// signaling a waiting Close once no work is left.
+func (w *Weaver) taskDone() {
+	if w.active.Add(-1) == 0 && w.closing.Load() {
+		w.quiesce()
 	}
-	if err := task(ctx); err != nil {
-		w.sendErr(err)
+}
+
+// quiesce signals Close that no task is queued or running anymore.
+func (w *Weaver) quiesce() {
+	w.quiesceOnce.Do(func() { close(w.quiesced) })
+}
+
+// fail reports a task error to the OnError callback and records it.
+func (w *Weaver) fail(id int, err error) {
+	if w.opts.onError != nil {
+		w.opts.onError(id, err)
 	}
+	w.sendErr(err)
 }
 
-// sendErr stores the first error encountered by any task.
-// Subsequent calls are ignored.
+// sendErr stores the first error encountered by any task and cancels the
+// workers' context, so in-flight tasks can stop early and queued ones are
+// skipped. Subsequent calls are ignored.
+//
+// With WithContinueOnError, every error is recorded instead and nothing is
+// canceled.
 func (w *Weaver) sendErr(err error) {
+	if w.opts.collect {
+		w.errMu.Lock()
+		w.errs = append(w.errs, err)
+		w.errMu.Unlock()
+		w.firstErr.CompareAndSwap(nil, &err)
+		return
+	}
+
 	w.errOnce.Do(func() {
+		w.firstErr.Store(&err)
 		w.errChan <- err
+		w.cancel()
 	})
 }
 
+// Err returns the first error recorded by any task so far, or nil.
+//
+// Unlike Wait, it does not block or close the Weaver, so it can be polled
+// while workers are still running, e.g. for monitoring. It is safe to call
+// concurrently.
+func (w *Weaver) Err() error {
+	if err := w.firstErr.Load(); err != nil {
+		return *err
+	}
+	return nil
+}
+
+// WithValue attaches a key/value pair to the context passed to tasks, as
+// context.WithValue would. Cancellation of the Weaver's context is preserved.
+//
+// The value is visible to every task that starts executing after WithValue
+// returns, including tasks that were already queued but not yet picked up by
+// a worker. Tasks that are already running keep the context they started
+// with. It is safe to call concurrently with Add and running tasks.
+func (w *Weaver) WithValue(key, val any) {
+	for {
+		old := w.taskCtx.Load()
+		next := context.WithValue(*old, key, val)
+		if w.taskCtx.CompareAndSwap(old, &next) {
+			return
+		}
+	}
+}
+
 // Add submits a task to the Weaver for execution.
 // It returns an error if the Weaver has already been closed
-// or if task submission occurs after Wait has begun.
+// or if task submission occurs after Wait has begun. If the queue is full, Add
+// blocks until there is room, or returns an error once the Weaver's context is
+// canceled (e.g. after a task failed).
 func (w *Weaver) Add(task Task) (err error) {
 	defer func() {
 		if r := recover(); r != nil {
+			w.taskDone()
 			err = errors.New("weave: cannot add task to closed weaver")
 		}
 	}()
-	if w.isClosed.Load() {
+	if !w.admit() {
 		return errors.New("weave: weaver is closed")
 	}
-	w.taskQueue <- task
-	return nil
+	select {
+	case w.taskQueue <- task:
+		return nil
+	case <-(*w.taskCtx.Load()).Done():
+		w.taskDone()
+		return errors.New("weave: weaver is stopped")
+	}
+}
+
+// AddCtx submits a task like Add, but gives up instead of blocking forever
+// when the queue is full.
+//
+// It returns ctx.Err() if ctx is done before the task could be enqueued, and
+// an error if the Weaver is closed or its own context has been canceled
+// (in which case no worker would ever pick the task up).
+func (w *Weaver) AddCtx(ctx context.Context, task Task) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			w.taskDone()
+			err = errors.New("weave: cannot add task to closed weaver")
+		}
+	}()
+	if !w.admit() {
+		return errors.New("weave: weaver is closed")
+	}
+
+	weaverCtx := *w.taskCtx.Load()
+	if weaverCtx.Err() != nil {
+		w.taskDone()
+		return errors.New("weave: weaver is stopped")
+	}
+
+	select {
+	case w.taskQueue <- task:
+		return nil
+	case <-weaverCtx.Done():
+		w.taskDone()
+		return errors.New("weave: weaver is stopped")
+	case <-ctx.Done():
+		w.taskDone()
+		return ctx.Err()
+	}
 }
 
 // Wait blocks until all tasks have completed or an error occurs.
 // It is idempotent and race-safe: multiple concurrent calls to Wait
 // are synchronized, and all callers receive the same final error.
 //
-// If any task returns an error or panics, that error is returned.
-// If the parent context is canceled, Wait returns ctx.Err().
-// Once Wait has returned, the Weaver is considered closed.
+// If any task returns an error or panics, that error is returned; panics are
+// reported as *PanicError. With WithContinueOnError, all task errors are
+// returned, joined with errors.Join.
+//
+// Cancellation of the parent context is not itself reported: queued tasks it
+// caused to be skipped are dropped silently, and Wait returns nil unless a
+// task returned an error (such as ctx.Err()). Use Status, which reports
+// StatusCanceled, or check ctx.Err() to detect it.
+//
+// Once Wait has returned, the Weaver is considered closed and its
+// context is released.
 func (w *Weaver) Wait() error {
-	// Fast-path: already closed
-	if w.isClosed.Load() {
-		<-w.errChan
-		return w.finalErr
+	defer w.cancel()
+	return w.Close()
+}
+
+// WaitContext behaves like Wait, but gives up waiting when ctx is done,
+// returning ctx.Err().
+//
+// ctx only bounds how long the caller blocks: it is independent of the
+// context the tasks run on, and giving up does not cancel them. The Weaver is
+// closed to new tasks either way, and its workers keep draining the queue in
+// the background, so a later Wait or WaitContext still returns the final
+// result. With WithRepanic, a task panic is re-raised here if it occurs
+// before ctx is done.
+func (w *Weaver) WaitContext(ctx context.Context) error {
+	type result struct {
+		err      error
+		panicVal any
+	}
+
+	done := make(chan result, 1)
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				done <- result{panicVal: r}
+			}
+		}()
+		done <- result{err: w.Wait()}
+	}()
+
+	select {
+	case res := <-done:
+		if res.panicVal != nil {
+			panic(res.panicVal)
+		}
+		return res.err
+	case <-ctx.Done():
+		return ctx.Err()
 	}
+}
 
-	// Attempt to become the closer
+// Close stops the Weaver from accepting new tasks and blocks until the
+// workers have drained every task already queued.
+//
+// Unlike Wait, Close does not cancel the context passed to running tasks
+// once they are done, which makes it suitable for separating "stop intake"
+// from "tear down". Add returns an error as soon as Close has been called.
+// Close returns the first task error, like Wait, and may be followed by Wait
+// (which returns the same error) to release the Weaver's context.
+//
+// With WithRepanic, the first task panic is re-raised here instead; concurrent
+// and later Close or Wait calls return an error wrapping ErrWaitPanicked.
+func (w *Weaver) Close() error {
+	// Attempt to become the closer; everyone else waits for the drain to finish.
 	if !w.isClosed.CompareAndSwap(false, true) {
-		<-w.errChan
+		<-w.drained
 		return w.finalErr
 	}
 
-	// We are the closer
+	// We are the closer. Whatever happens, finalErr must be settled before
+	// drained is closed so every other caller observes the same outcome.
 	defer func() {
-		w.cancel()
 		if r := recover(); r != nil {
-			w.finalErr = fmt.Errorf("weaver: wait panic: %v", r)
-			close(w.errChan)
+			w.finalErr = fmt.Errorf("%w: %w", ErrWaitPanicked, &PanicError{value: r})
+			w.status.Store(int32(StatusFailed))
+			close(w.drained)
 			panic(r)
-		} else {
-			close(w.errChan)
 		}
+		w.status.Store(int32(w.resolveStatus()))
+		close(w.drained)
 	}()
 
+	// Let queued and running tasks finish first, since they may Spawn more
+	// work. A canceled context means nothing more will run anyway.
+	// Wait for Adds that were admitted before isClosed was set; any later
+	// ones are rejected, so active can only grow through Spawn from here on.
+	w.intakeMu.Lock()
+	w.closing.Store(true)
+	w.intakeMu.Unlock()
+	if w.active.Load() == 0 {
+		w.quiesce()
+	}
+	select {
+	case <-w.quiesced:
+	case <-(*w.taskCtx.Load()).Done():
+	}
+
 	close(w.taskQueue)
 	w.wg.Wait()
 
+	// All workers have stopped, so panicValue is stable.
+	if w.panicValue != nil {
+		panic(w.panicValue)
+	}
+
+	if w.opts.collect {
+		w.finalErr = errors.Join(w.errs...)
+		return w.finalErr
+	}
+
 	select {
 	case err := <-w.errChan:
 		w.finalErr = err